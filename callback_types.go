@@ -0,0 +1,193 @@
+package steamkit
+
+import (
+	"encoding/binary"
+)
+
+// 内置回调 ID（k_iCallback）
+const (
+	CallbackIDSteamServersConnected      CallbackID = 101
+	CallbackIDSteamServerConnectFailure  CallbackID = 102
+	CallbackIDSteamServersDisconnected   CallbackID = 103
+	CallbackIDEncryptedAppTicketResponse CallbackID = 154
+	CallbackIDPersonaStateChange         CallbackID = 304
+	CallbackIDGameOverlayActivated       CallbackID = 331
+	CallbackIDIPCountry                  CallbackID = 701
+	CallbackIDLowBatteryPower            CallbackID = 702
+	CallbackIDSteamAPICallCompleted      CallbackID = 703
+	CallbackIDSteamShutdown              CallbackID = 704
+)
+
+// SteamServersConnected 表示已连接到 Steam 服务器（SteamServersConnected_t）
+type SteamServersConnected struct{}
+
+// CallbackID 实现 Callback 接口
+func (SteamServersConnected) CallbackID() CallbackID { return CallbackIDSteamServersConnected }
+
+// SteamServerConnectFailure 表示连接 Steam 服务器失败（SteamServerConnectFailure_t）
+type SteamServerConnectFailure struct {
+	Result        EResult // 失败原因
+	StillRetrying bool    // 是否仍在重试
+}
+
+// CallbackID 实现 Callback 接口
+func (SteamServerConnectFailure) CallbackID() CallbackID { return CallbackIDSteamServerConnectFailure }
+
+// SteamServersDisconnected 表示与 Steam 服务器断开连接（SteamServersDisconnected_t）
+type SteamServersDisconnected struct {
+	Result EResult // 断开原因
+}
+
+// CallbackID 实现 Callback 接口
+func (SteamServersDisconnected) CallbackID() CallbackID { return CallbackIDSteamServersDisconnected }
+
+// EncryptedAppTicketResponse 是 RequestEncryptedAppTicket 的结果（EncryptedAppTicketResponse_t）
+type EncryptedAppTicketResponse struct {
+	Result EResult // 请求结果
+}
+
+// CallbackID 实现 Callback 接口
+func (EncryptedAppTicketResponse) CallbackID() CallbackID {
+	return CallbackIDEncryptedAppTicketResponse
+}
+
+// PersonaChange 表示好友信息变化的内容（EPersonaChange）
+type PersonaChange int32
+
+const (
+	PersonaChangeName                PersonaChange = 0x0001
+	PersonaChangeStatus              PersonaChange = 0x0002
+	PersonaChangeComeOnline          PersonaChange = 0x0004
+	PersonaChangeGoneOffline         PersonaChange = 0x0008
+	PersonaChangeGamePlayed          PersonaChange = 0x0010
+	PersonaChangeGameServer          PersonaChange = 0x0020
+	PersonaChangeAvatar              PersonaChange = 0x0040
+	PersonaChangeJoinedSource        PersonaChange = 0x0080
+	PersonaChangeLeftSource          PersonaChange = 0x0100
+	PersonaChangeRelationshipChanged PersonaChange = 0x0200
+	PersonaChangeNameFirstSet        PersonaChange = 0x0400
+	PersonaChangeBroadcast           PersonaChange = 0x0800
+	PersonaChangeNickname            PersonaChange = 0x1000
+	PersonaChangeSteamLevel          PersonaChange = 0x2000
+	PersonaChangeRichPresence        PersonaChange = 0x4000
+)
+
+// Has 检查是否包含指定的变化
+func (c PersonaChange) Has(flag PersonaChange) bool {
+	return c&flag != 0
+}
+
+// PersonaStateChange 表示好友或用户的信息发生变化（PersonaStateChange_t）
+type PersonaStateChange struct {
+	SteamID     SteamID       // 信息发生变化的用户
+	ChangeFlags PersonaChange // 变化内容
+}
+
+// CallbackID 实现 Callback 接口
+func (PersonaStateChange) CallbackID() CallbackID { return CallbackIDPersonaStateChange }
+
+// GameOverlayActivated 表示 Steam 覆盖界面被打开或关闭（GameOverlayActivated_t）
+type GameOverlayActivated struct {
+	Active        bool   // 覆盖界面是否处于打开状态
+	UserInitiated bool   // 是否由用户操作触发
+	AppID         uint32 // 触发覆盖界面的应用
+	OverlayPID    uint32 // 覆盖界面进程 ID
+}
+
+// CallbackID 实现 Callback 接口
+func (GameOverlayActivated) CallbackID() CallbackID { return CallbackIDGameOverlayActivated }
+
+// IPCountry 表示用户所在国家发生变化（IPCountry_t）
+type IPCountry struct{}
+
+// CallbackID 实现 Callback 接口
+func (IPCountry) CallbackID() CallbackID { return CallbackIDIPCountry }
+
+// LowBatteryPower 表示电池电量不足（LowBatteryPower_t）
+type LowBatteryPower struct {
+	MinutesBatteryLeft uint8 // 剩余分钟数
+}
+
+// CallbackID 实现 Callback 接口
+func (LowBatteryPower) CallbackID() CallbackID { return CallbackIDLowBatteryPower }
+
+// SteamAPICallCompleted 表示一个异步调用已完成（SteamAPICallCompleted_t）
+type SteamAPICallCompleted struct {
+	Call      uint64     // 异步调用句柄（SteamAPICall_t）
+	Callback  CallbackID // 结果结构体的回调 ID
+	ParamSize uint32     // 结果结构体大小
+}
+
+// CallbackID 实现 Callback 接口
+func (SteamAPICallCompleted) CallbackID() CallbackID { return CallbackIDSteamAPICallCompleted }
+
+// steamAPICallCompletedSize 是 SteamAPICallCompleted_t 的大小
+const steamAPICallCompletedSize = 16
+
+// decodeSteamAPICallCompleted 解码 SteamAPICallCompleted_t
+func decodeSteamAPICallCompleted(b []byte) SteamAPICallCompleted {
+	return SteamAPICallCompleted{
+		Call:      binary.LittleEndian.Uint64(b[0:]),
+		Callback:  CallbackID(int32(binary.LittleEndian.Uint32(b[8:]))),
+		ParamSize: binary.LittleEndian.Uint32(b[12:]),
+	}
+}
+
+// SteamShutdown 表示 Steam 即将关闭（SteamShutdown_t）
+type SteamShutdown struct{}
+
+// CallbackID 实现 Callback 接口
+func (SteamShutdown) CallbackID() CallbackID { return CallbackIDSteamShutdown }
+
+// 内置回调的解码器
+func init() {
+	RegisterCallback(CallbackIDSteamServersConnected, 0, func([]byte) Callback {
+		return SteamServersConnected{}
+	})
+	RegisterCallback(CallbackIDSteamServerConnectFailure, 5, func(b []byte) Callback {
+		return SteamServerConnectFailure{
+			Result:        EResult(int32(binary.LittleEndian.Uint32(b[0:]))),
+			StillRetrying: b[4] != 0,
+		}
+	})
+	RegisterCallback(CallbackIDSteamServersDisconnected, 4, func(b []byte) Callback {
+		return SteamServersDisconnected{
+			Result: EResult(int32(binary.LittleEndian.Uint32(b[0:]))),
+		}
+	})
+	RegisterCallback(CallbackIDEncryptedAppTicketResponse, 4, func(b []byte) Callback {
+		return EncryptedAppTicketResponse{
+			Result: EResult(int32(binary.LittleEndian.Uint32(b[0:]))),
+		}
+	})
+	RegisterCallback(CallbackIDPersonaStateChange, 12, func(b []byte) Callback {
+		return PersonaStateChange{
+			SteamID:     SteamID(binary.LittleEndian.Uint64(b[0:])),
+			ChangeFlags: PersonaChange(int32(binary.LittleEndian.Uint32(b[8:]))),
+		}
+	})
+	RegisterCallback(CallbackIDGameOverlayActivated, 8, func(b []byte) Callback {
+		event := GameOverlayActivated{
+			Active:        b[0] != 0,
+			UserInitiated: b[1] != 0,
+			AppID:         binary.LittleEndian.Uint32(b[4:]),
+		}
+		// 较早的 SDK 版本没有 m_dwOverlayPID
+		if len(b) >= 12 {
+			event.OverlayPID = binary.LittleEndian.Uint32(b[8:])
+		}
+		return event
+	})
+	RegisterCallback(CallbackIDIPCountry, 0, func([]byte) Callback {
+		return IPCountry{}
+	})
+	RegisterCallback(CallbackIDLowBatteryPower, 1, func(b []byte) Callback {
+		return LowBatteryPower{MinutesBatteryLeft: b[0]}
+	})
+	RegisterCallback(CallbackIDSteamAPICallCompleted, steamAPICallCompletedSize, func(b []byte) Callback {
+		return decodeSteamAPICallCompleted(b)
+	})
+	RegisterCallback(CallbackIDSteamShutdown, 0, func([]byte) Callback {
+		return SteamShutdown{}
+	})
+}
@@ -0,0 +1,309 @@
+package steamkit
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/guowei-gong/steamkit-go/internal/purego"
+)
+
+// DefaultCallbackInterval 是 StartCallbackLoop 的默认帧间隔
+const DefaultCallbackInterval = 16 * time.Millisecond
+
+// ErrCallbackLoopRunning 表示已有回调循环在运行
+var ErrCallbackLoopRunning = errors.New("steamkit: callback loop already running")
+
+// CallbackPanic 描述订阅者在回调中发生的 panic
+type CallbackPanic struct {
+	Callback CallbackID // 发生 panic 的回调 ID
+	Value    any        // recover() 的返回值
+	Stack    string     // panic 时的调用栈
+}
+
+// CallbackPanicHandler 是回调 panic 的报告函数
+type CallbackPanicHandler func(p CallbackPanic)
+
+// panicHandler 是当前的 panic 报告函数，nil 表示使用 LogCallbackPanic
+var panicHandler atomic.Pointer[CallbackPanicHandler]
+
+// SetCallbackPanicHandler 设置回调循环中 panic 的报告函数
+// 传入 nil 恢复默认的 LogCallbackPanic
+func SetCallbackPanicHandler(handler CallbackPanicHandler) {
+	if handler == nil {
+		panicHandler.Store(nil)
+		return
+	}
+	panicHandler.Store(&handler)
+}
+
+// LogCallbackPanic 是将 panic 写入标准日志的 CallbackPanicHandler
+func LogCallbackPanic(p CallbackPanic) {
+	log.Printf("steamkit: recovered panic in callback %d: %v\n%s", p.Callback, p.Value, p.Stack)
+}
+
+// reportCallbackPanic 报告回调 panic
+func reportCallbackPanic(p CallbackPanic) {
+	if handler := panicHandler.Load(); handler != nil {
+		(*handler)(p)
+		return
+	}
+	LogCallbackPanic(p)
+}
+
+// CallbackLoopStats 是回调循环的运行统计
+type CallbackLoopStats struct {
+	Frames     uint64        // 已执行的帧数
+	Callbacks  uint64        // 已分发的回调数
+	Panics     uint64        // 已恢复的 panic 数
+	LastFrame  time.Duration // 最近一帧的耗时
+	MaxFrame   time.Duration // 单帧最大耗时
+	TotalFrame time.Duration // 所有帧的总耗时
+}
+
+// AvgFrame 返回平均每帧耗时
+func (s CallbackLoopStats) AvgFrame() time.Duration {
+	if s.Frames == 0 {
+		return 0
+	}
+	return s.TotalFrame / time.Duration(s.Frames)
+}
+
+// CallbackLoop 是在后台定期分发回调的循环
+type CallbackLoop struct {
+	interval time.Duration
+	frame    func(onPanic func(id CallbackID, value any)) int
+
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	goroutine        atomic.Uint64 // 运行循环的 goroutine ID，用于识别回调中的 Shutdown
+	pendingShutdowns atomic.Int32  // 回调中调用、推迟到循环退出后执行的 Shutdown 次数
+
+	mu    sync.Mutex
+	stats CallbackLoopStats
+}
+
+var (
+	callbackLoopMu     sync.Mutex
+	activeCallbackLoop *CallbackLoop
+)
+
+// StartCallbackLoop 启动后台回调循环，每隔 interval 执行一次 RunCallbacks
+// 以及子系统注册的帧钩子（例如获取 steamnet.GetSockets 后的 ISteamNetworkingSockets::RunCallbacks），
+// 使用循环时不需要再手动调用这些函数。
+// 循环运行在锁定的 OS 线程上；订阅者的 panic 会被恢复并通过 SetCallbackPanicHandler
+// 设置的函数报告，不会导致进程崩溃。
+// ctx 取消、调用 Stop 或 Shutdown 时循环结束，同一时间只能运行一个循环。
+// interval <= 0 时使用 DefaultCallbackInterval。
+func StartCallbackLoop(ctx context.Context, interval time.Duration) (*CallbackLoop, error) {
+	callbackLoopMu.Lock()
+	defer callbackLoopMu.Unlock()
+
+	if activeCallbackLoop != nil {
+		select {
+		case <-activeCallbackLoop.done:
+		default:
+			return nil, ErrCallbackLoopRunning
+		}
+	}
+
+	l := newCallbackLoop(interval, func(onPanic func(CallbackID, any)) int {
+		n := runSteamCallbacks(onPanic)
+		runFrameHooks(onPanic)
+		return n
+	})
+	l.start(ctx)
+	activeCallbackLoop = l
+	return l, nil
+}
+
+// runFrameHooks 依次执行子系统注册的帧钩子，单个钩子的 panic 不影响其他钩子
+func runFrameHooks(onPanic func(CallbackID, any)) {
+	for _, fn := range purego.FrameHooks() {
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					onPanic(0, fmt.Sprintf("frame hook: %v", r))
+				}
+			}()
+			fn()
+		}()
+	}
+}
+
+// stopCallbackLoop 停止正在运行的回调循环并等待其退出
+// 循环退出之前仍然是活动循环，使停止期间回调中的 Shutdown / Init 能够识别出自己在循环中
+func stopCallbackLoop() {
+	callbackLoopMu.Lock()
+	l := activeCallbackLoop
+	callbackLoopMu.Unlock()
+
+	if l == nil {
+		return
+	}
+	l.Stop()
+
+	callbackLoopMu.Lock()
+	if activeCallbackLoop == l {
+		activeCallbackLoop = nil
+	}
+	callbackLoopMu.Unlock()
+}
+
+// currentCallbackLoop 返回当前 goroutine 正在运行的回调循环，不在循环中时返回 nil
+func currentCallbackLoop() *CallbackLoop {
+	callbackLoopMu.Lock()
+	l := activeCallbackLoop
+	callbackLoopMu.Unlock()
+
+	if l == nil || l.goroutine.Load() != goroutineID() {
+		return nil
+	}
+	return l
+}
+
+// onCallbackLoop 检查当前 goroutine 是否为回调循环
+func onCallbackLoop() bool {
+	return currentCallbackLoop() != nil
+}
+
+// deferShutdownToCallbackLoop 在回调循环的 goroutine 中调用 Shutdown 时返回 true，
+// 并让循环在当前帧结束后退出再执行 Shutdown（在循环中等待循环退出会等待自身）
+func deferShutdownToCallbackLoop() bool {
+	l := currentCallbackLoop()
+	if l == nil {
+		return false
+	}
+	l.pendingShutdowns.Add(1)
+	l.cancel()
+	return true
+}
+
+// goroutineID 返回当前 goroutine 的 ID（从调用栈的第一行 "goroutine N [" 解析）
+func goroutineID() uint64 {
+	var buf [64]byte
+	b := buf[:runtime.Stack(buf[:], false)]
+	b = bytes.TrimPrefix(b, []byte("goroutine "))
+	if i := bytes.IndexByte(b, ' '); i >= 0 {
+		b = b[:i]
+	}
+	id, _ := strconv.ParseUint(string(b), 10, 64)
+	return id
+}
+
+// newCallbackLoop 创建回调循环
+func newCallbackLoop(interval time.Duration, frame func(onPanic func(CallbackID, any)) int) *CallbackLoop {
+	if interval <= 0 {
+		interval = DefaultCallbackInterval
+	}
+	return &CallbackLoop{
+		interval: interval,
+		frame:    frame,
+		done:     make(chan struct{}),
+	}
+}
+
+// start 在新的 goroutine 中运行循环
+func (l *CallbackLoop) start(ctx context.Context) {
+	ctx, l.cancel = context.WithCancel(ctx)
+	go l.run(ctx)
+}
+
+// run 是循环主体
+func (l *CallbackLoop) run(ctx context.Context) {
+	l.goroutine.Store(goroutineID())
+	defer func() {
+		l.goroutine.Store(0)
+		close(l.done)
+		// 执行回调中推迟的 Shutdown
+		for n := l.pendingShutdowns.Load(); n > 0; n-- {
+			Shutdown()
+		}
+	}()
+
+	// Steam 回调要求在固定线程上分发
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	ticker := time.NewTicker(l.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// 取消与定时器同时就绪时不再执行新的一帧
+			if ctx.Err() != nil {
+				return
+			}
+			l.runFrame()
+		}
+	}
+}
+
+// runFrame 执行一帧并更新统计
+func (l *CallbackLoop) runFrame() {
+	var panics uint64
+	onPanic := func(id CallbackID, value any) {
+		panics++
+		reportCallbackPanic(CallbackPanic{
+			Callback: id,
+			Value:    value,
+			Stack:    string(debug.Stack()),
+		})
+	}
+
+	start := time.Now()
+	n := l.safeFrame(onPanic)
+	elapsed := time.Since(start)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.stats.Frames++
+	l.stats.Callbacks += uint64(n)
+	l.stats.Panics += panics
+	l.stats.LastFrame = elapsed
+	l.stats.TotalFrame += elapsed
+	if elapsed > l.stats.MaxFrame {
+		l.stats.MaxFrame = elapsed
+	}
+}
+
+// safeFrame 执行一帧，恢复逐个回调之外（如原生调用本身）发生的 panic
+func (l *CallbackLoop) safeFrame(onPanic func(CallbackID, any)) (n int) {
+	defer func() {
+		if r := recover(); r != nil {
+			onPanic(0, fmt.Sprintf("callback frame: %v", r))
+		}
+	}()
+	return l.frame(onPanic)
+}
+
+// Stop 停止循环并等待当前帧结束，可重复调用
+// 不能在回调中调用，否则会等待自身而死锁
+func (l *CallbackLoop) Stop() {
+	l.cancel()
+	<-l.done
+}
+
+// Done 返回在循环退出后关闭的 channel
+func (l *CallbackLoop) Done() <-chan struct{} {
+	return l.done
+}
+
+// Stats 返回循环运行统计的快照
+func (l *CallbackLoop) Stats() CallbackLoopStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.stats
+}
@@ -0,0 +1,255 @@
+package steamkit
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/guowei-gong/steamkit-go/internal/purego"
+)
+
+func TestCallbackLoop_RecoversPanics(t *testing.T) {
+	r := newCallbackRegistry()
+	r.register(CallbackIDSteamShutdown, 0, func([]byte) Callback { return SteamShutdown{} })
+
+	delivered := make(chan struct{}, 16)
+	r.subscribe(CallbackIDSteamShutdown, func(Callback) { panic("boom") })
+	r.subscribeRaw(CallbackIDLowBatteryPower, func(CallbackID, []byte) { delivered <- struct{}{} })
+
+	panics := make(chan CallbackPanic, 16)
+	SetCallbackPanicHandler(func(p CallbackPanic) { panics <- p })
+	defer SetCallbackPanicHandler(nil)
+
+	// 每帧分发一个会 panic 的回调和一个正常回调
+	frame := func(onPanic func(CallbackID, any)) int {
+		for _, id := range []CallbackID{CallbackIDSteamShutdown, CallbackIDLowBatteryPower} {
+			func() {
+				defer func() {
+					if v := recover(); v != nil {
+						onPanic(id, v)
+					}
+				}()
+				r.dispatch(id, []byte{1})
+			}()
+		}
+		return 2
+	}
+
+	l := newCallbackLoop(time.Millisecond, frame)
+	l.start(context.Background())
+
+	select {
+	case p := <-panics:
+		if p.Callback != CallbackIDSteamShutdown || p.Value != "boom" || p.Stack == "" {
+			t.Errorf("CallbackPanic = {%d, %v, %d bytes of stack}", p.Callback, p.Value, len(p.Stack))
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("panic was not reported")
+	}
+	select {
+	case <-delivered:
+	case <-time.After(5 * time.Second):
+		t.Fatal("callback after panic was not delivered")
+	}
+
+	l.Stop()
+	l.Stop()
+
+	stats := l.Stats()
+	if stats.Frames == 0 || stats.Callbacks != 2*stats.Frames || stats.Panics != stats.Frames {
+		t.Errorf("Stats = %+v, want 2 callbacks and 1 panic per frame", stats)
+	}
+	if stats.MaxFrame < stats.LastFrame || stats.AvgFrame() > stats.MaxFrame {
+		t.Errorf("inconsistent frame timings: %+v", stats)
+	}
+}
+
+func TestCallbackLoop_FramePanic(t *testing.T) {
+	panics := make(chan CallbackPanic, 16)
+	SetCallbackPanicHandler(func(p CallbackPanic) { panics <- p })
+	defer SetCallbackPanicHandler(nil)
+
+	l := newCallbackLoop(time.Millisecond, func(func(CallbackID, any)) int {
+		panic("native failure")
+	})
+	l.start(context.Background())
+	defer l.Stop()
+
+	select {
+	case <-panics:
+	case <-time.After(5 * time.Second):
+		t.Fatal("frame panic was not reported")
+	}
+}
+
+func TestCallbackLoop_ContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	l := newCallbackLoop(0, func(func(CallbackID, any)) int { return 0 })
+	if l.interval != DefaultCallbackInterval {
+		t.Errorf("interval = %v, want %v", l.interval, DefaultCallbackInterval)
+	}
+	l.start(ctx)
+	cancel()
+
+	select {
+	case <-l.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("loop did not stop after context cancel")
+	}
+}
+
+func TestStartCallbackLoop_Single(t *testing.T) {
+	l, err := StartCallbackLoop(context.Background(), time.Millisecond)
+	if err != nil {
+		t.Fatalf("StartCallbackLoop() error = %v", err)
+	}
+	if _, err := StartCallbackLoop(context.Background(), time.Millisecond); err != ErrCallbackLoopRunning {
+		t.Errorf("second StartCallbackLoop() error = %v, want ErrCallbackLoopRunning", err)
+	}
+
+	stopCallbackLoop()
+	select {
+	case <-l.Done():
+	default:
+		t.Fatal("stopCallbackLoop() did not stop the loop")
+	}
+
+	l2, err := StartCallbackLoop(context.Background(), time.Millisecond)
+	if err != nil {
+		t.Fatalf("StartCallbackLoop() after stop error = %v", err)
+	}
+	stopCallbackLoop()
+	<-l2.Done()
+}
+
+// 测试循环每帧执行子系统注册的帧钩子（如 ISteamNetworkingSockets::RunCallbacks）
+func TestStartCallbackLoop_FrameHooks(t *testing.T) {
+	called := make(chan struct{}, 1)
+	purego.SetFrameHook("test", func() {
+		select {
+		case called <- struct{}{}:
+		default:
+		}
+	})
+	t.Cleanup(func() { purego.SetFrameHook("test", nil) })
+
+	l, err := StartCallbackLoop(context.Background(), time.Millisecond)
+	if err != nil {
+		t.Fatalf("StartCallbackLoop() error = %v", err)
+	}
+	defer l.Stop()
+
+	select {
+	case <-called:
+	case <-time.After(5 * time.Second):
+		t.Fatal("frame hook was not called by the callback loop")
+	}
+}
+
+// 测试在回调中调用 Shutdown 不会等待循环自身
+func TestCallbackLoop_ShutdownFromCallback(t *testing.T) {
+	returned := make(chan struct{}, 1)
+	var l *CallbackLoop
+	l = newCallbackLoop(time.Millisecond, func(func(CallbackID, any)) int {
+		Shutdown()
+		select {
+		case returned <- struct{}{}:
+		default:
+		}
+		return 0
+	})
+
+	callbackLoopMu.Lock()
+	activeCallbackLoop = l
+	callbackLoopMu.Unlock()
+	l.start(context.Background())
+
+	select {
+	case <-l.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("Shutdown in callback deadlocked the loop")
+	}
+	if len(returned) != 1 {
+		t.Error("Shutdown in callback did not return")
+	}
+	if l.pendingShutdowns.Load() != 1 {
+		t.Errorf("pendingShutdowns = %d, want 1", l.pendingShutdowns.Load())
+	}
+
+	// 循环之外的 goroutine 不会被推迟
+	if deferShutdownToCallbackLoop() {
+		t.Error("deferShutdownToCallbackLoop() = true outside the loop")
+	}
+	stopCallbackLoop()
+}
+
+// 测试并发的 Init / Shutdown 与回调循环：持有引用期间循环不会被停止，
+// 真正关闭时循环已经停止
+func TestShutdown_ConcurrentWithCallbackLoop(t *testing.T) {
+	var shutdowns, running atomic.Int32
+	orig := shutdownNative
+	shutdownNative = func() {
+		shutdowns.Add(1)
+		callbackLoopMu.Lock()
+		l := activeCallbackLoop
+		callbackLoopMu.Unlock()
+		if l != nil {
+			select {
+			case <-l.Done():
+			default:
+				running.Add(1)
+			}
+		}
+	}
+	t.Cleanup(func() { shutdownNative = orig })
+
+	var stopped atomic.Int32
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				// 模拟 Init：不加载原生库，只增加引用计数
+				if err := purego.Acquire(func() error { return nil }); err != nil {
+					t.Error(err)
+					return
+				}
+				l, err := StartCallbackLoop(context.Background(), time.Millisecond)
+				if errors.Is(err, ErrCallbackLoopRunning) {
+					callbackLoopMu.Lock()
+					l = activeCallbackLoop
+					callbackLoopMu.Unlock()
+				} else if err != nil {
+					t.Error(err)
+					return
+				}
+				time.Sleep(50 * time.Microsecond)
+				// 当前 goroutine 仍持有引用，循环不应被其他 goroutine 的 Shutdown 停止
+				select {
+				case <-l.Done():
+					stopped.Add(1)
+				default:
+				}
+				Shutdown()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := stopped.Load(); n != 0 {
+		t.Errorf("callback loop stopped %d times while a reference was held", n)
+	}
+	if n := running.Load(); n != 0 {
+		t.Errorf("SteamAPI_Shutdown ran %d times with the callback loop still running", n)
+	}
+	if shutdowns.Load() == 0 {
+		t.Error("SteamAPI_Shutdown was never called")
+	}
+	if purego.Initialized() {
+		t.Error("Steam API still initialized after all Shutdowns")
+	}
+}
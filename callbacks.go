@@ -0,0 +1,237 @@
+package steamkit
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/guowei-gong/steamkit-go/internal/purego"
+)
+
+// CallbackID 是 Steamworks 回调结构体的 k_iCallback 值
+type CallbackID int32
+
+// Callback 是所有类型化回调事件实现的接口
+type Callback interface {
+	// CallbackID 返回事件对应的回调 ID，在零值上调用也必须有效
+	CallbackID() CallbackID
+}
+
+// CallbackDecoder 将原生回调结构体解码为类型化事件
+// data 指向原生内存，解码器不能保留它
+type CallbackDecoder func(data []byte) Callback
+
+// RawCallbackHandler 处理未经解码的原生回调
+// data 只在调用期间有效，需要保留时必须复制
+type RawCallbackHandler func(id CallbackID, data []byte)
+
+// callbackDecoderEntry 是已注册的解码器
+type callbackDecoderEntry struct {
+	size   int // 原生结构体的最小大小
+	decode CallbackDecoder
+}
+
+// callbackSubscription 是一个订阅，使用指针区分不同订阅
+type callbackSubscription struct {
+	fn func(Callback)
+}
+
+// rawSubscription 是一个原始回调订阅
+type rawSubscription struct {
+	fn RawCallbackHandler
+}
+
+// callbackRegistry 管理回调 ID 到解码器和订阅者的映射
+type callbackRegistry struct {
+	mu       sync.RWMutex
+	decoders map[CallbackID]callbackDecoderEntry
+	handlers map[CallbackID][]*callbackSubscription
+	raw      map[CallbackID][]*rawSubscription
+}
+
+var (
+	globalCallbackRegistry = newCallbackRegistry()
+)
+
+// newCallbackRegistry 创建回调注册表
+func newCallbackRegistry() *callbackRegistry {
+	return &callbackRegistry{
+		decoders: make(map[CallbackID]callbackDecoderEntry),
+		handlers: make(map[CallbackID][]*callbackSubscription),
+		raw:      make(map[CallbackID][]*rawSubscription),
+	}
+}
+
+// RegisterCallback 为回调 ID 注册解码器
+// size 是原生结构体的最小大小，数据不足时回调会被丢弃
+// 各子系统通过它接入自己的回调类型，重复注册会覆盖之前的解码器
+func RegisterCallback(id CallbackID, size int, decode CallbackDecoder) {
+	globalCallbackRegistry.register(id, size, decode)
+}
+
+// On 订阅类型为 T 的回调事件，返回取消订阅的函数
+// 回调在 RunCallbacks 期间、在调用 RunCallbacks 的 goroutine 上执行
+// 只有注册了解码器的回调才会分发给 On：内置的类型化事件见 callback_types.go
+// （SteamServersConnected、SteamServerConnectFailure、SteamServersDisconnected、EncryptedAppTicketResponse、
+// PersonaStateChange、GameOverlayActivated、IPCountry、LowBatteryPower、SteamAPICallCompleted、SteamShutdown），
+// 其他回调使用 OnRaw 订阅，或通过 RegisterCallback 注册解码器，运行时可用 RegisteredCallbacks 查询
+//
+//	cancel := steamkit.On(func(e steamkit.PersonaStateChange) { ... })
+//	defer cancel()
+func On[T Callback](fn func(T)) func() {
+	var zero T
+	return globalCallbackRegistry.subscribe(zero.CallbackID(), func(cb Callback) {
+		if event, ok := cb.(T); ok {
+			fn(event)
+		}
+	})
+}
+
+// OnRaw 订阅指定 ID 的原始回调，适用于没有注册解码器的回调
+// 返回取消订阅的函数
+func OnRaw(id CallbackID, fn RawCallbackHandler) func() {
+	return globalCallbackRegistry.subscribeRaw(id, fn)
+}
+
+// RegisteredCallbacks 返回所有已注册解码器的回调 ID（升序）
+func RegisteredCallbacks() []CallbackID {
+	return globalCallbackRegistry.registered()
+}
+
+// register 注册解码器
+func (r *callbackRegistry) register(id CallbackID, size int, decode CallbackDecoder) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.decoders[id] = callbackDecoderEntry{size: size, decode: decode}
+}
+
+// registered 返回已注册的回调 ID
+func (r *callbackRegistry) registered() []CallbackID {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ids := make([]CallbackID, 0, len(r.decoders))
+	for id := range r.decoders {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// subscribe 添加类型化订阅
+func (r *callbackRegistry) subscribe(id CallbackID, fn func(Callback)) func() {
+	sub := &callbackSubscription{fn: fn}
+
+	r.mu.Lock()
+	r.handlers[id] = append(r.handlers[id], sub)
+	r.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			r.handlers[id] = removeSubscription(r.handlers[id], sub)
+		})
+	}
+}
+
+// subscribeRaw 添加原始订阅
+func (r *callbackRegistry) subscribeRaw(id CallbackID, fn RawCallbackHandler) func() {
+	sub := &rawSubscription{fn: fn}
+
+	r.mu.Lock()
+	r.raw[id] = append(r.raw[id], sub)
+	r.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			r.raw[id] = removeSubscription(r.raw[id], sub)
+		})
+	}
+}
+
+// removeSubscription 从订阅列表中移除 sub，返回新的列表
+// 总是创建新切片，正在分发的旧快照不受影响
+func removeSubscription[S comparable](subs []S, sub S) []S {
+	out := make([]S, 0, len(subs))
+	for _, s := range subs {
+		if s != sub {
+			out = append(out, s)
+		}
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+// dispatch 将一个原生回调分发给订阅者
+// 先调用原始订阅者，再解码并调用类型化订阅者；没有订阅者时不解码
+func (r *callbackRegistry) dispatch(id CallbackID, data []byte) {
+	r.mu.RLock()
+	raw := r.raw[id]
+	handlers := r.handlers[id]
+	entry, known := r.decoders[id]
+	r.mu.RUnlock()
+
+	for _, sub := range raw {
+		sub.fn(id, data)
+	}
+
+	if len(handlers) == 0 || !known || len(data) < entry.size {
+		return
+	}
+
+	event := entry.decode(data)
+	for _, sub := range handlers {
+		sub.fn(event)
+	}
+}
+
+// manualDispatch 表示是否已启用手动回调分发
+// Init / Shutdown 写入，回调循环的 goroutine 读取
+var manualDispatch atomic.Bool
+
+// pumpCallbacks 执行一帧手动分发，取出并分发所有待处理的回调
+// onPanic 不为 nil 时，订阅者的 panic 会被恢复并报告给 onPanic，之后继续分发后续回调
+// 返回本帧分发的回调数量
+func pumpCallbacks(onPanic func(id CallbackID, value any)) int {
+	pipe := purego.CallGetHSteamPipe()
+	purego.CallManualDispatchRunFrame(pipe)
+
+	n := 0
+	for dispatchNextCallback(pipe, onPanic) {
+		n++
+	}
+	return n
+}
+
+// dispatchNextCallback 取出并分发一个回调，没有待处理的回调时返回 false
+func dispatchNextCallback(pipe int32, onPanic func(id CallbackID, value any)) (more bool) {
+	id, data, ok := purego.CallManualDispatchGetNextCallback(pipe)
+	if !ok {
+		return false
+	}
+	// 即使订阅者 panic，也要释放原生回调
+	defer purego.CallManualDispatchFreeLastCallback(pipe)
+	if onPanic != nil {
+		defer func() {
+			if r := recover(); r != nil {
+				onPanic(CallbackID(id), r)
+			}
+		}()
+	}
+	more = true
+
+	// 异步调用结果必须在 FreeLastCallback 之前取回
+	if CallbackID(id) == CallbackIDSteamAPICallCompleted && len(data) >= steamAPICallCompletedSize {
+		globalCallResults.handleCallCompleted(pipe, decodeSteamAPICallCompleted(data))
+	}
+
+	globalCallbackRegistry.dispatch(CallbackID(id), data)
+	return more
+}
@@ -0,0 +1,175 @@
+package steamkit
+
+import (
+	"encoding/binary"
+	"slices"
+	"testing"
+)
+
+func TestOn_PersonaStateChange(t *testing.T) {
+	var got []PersonaStateChange
+	cancel := On(func(e PersonaStateChange) {
+		got = append(got, e)
+	})
+
+	b := make([]byte, 12)
+	binary.LittleEndian.PutUint64(b[0:], 76561198000000000)
+	binary.LittleEndian.PutUint32(b[8:], uint32(PersonaChangeName|PersonaChangeAvatar))
+	globalCallbackRegistry.dispatch(CallbackIDPersonaStateChange, b)
+
+	cancel()
+	globalCallbackRegistry.dispatch(CallbackIDPersonaStateChange, b)
+
+	if len(got) != 1 {
+		t.Fatalf("handler called %d times, want 1", len(got))
+	}
+	if got[0].SteamID != 76561198000000000 {
+		t.Errorf("SteamID = %d, want 76561198000000000", got[0].SteamID)
+	}
+	if !got[0].ChangeFlags.Has(PersonaChangeAvatar) || got[0].ChangeFlags.Has(PersonaChangeStatus) {
+		t.Errorf("ChangeFlags = 0x%x, want Name|Avatar", int32(got[0].ChangeFlags))
+	}
+}
+
+func TestCallbackRegistry_Dispatch(t *testing.T) {
+	r := newCallbackRegistry()
+	r.register(CallbackIDSteamServersDisconnected, 4, func(b []byte) Callback {
+		return SteamServersDisconnected{Result: EResult(int32(binary.LittleEndian.Uint32(b)))}
+	})
+
+	var typed, other int
+	var raw []int
+	cancel := r.subscribe(CallbackIDSteamServersDisconnected, func(cb Callback) {
+		if e := cb.(SteamServersDisconnected); e.Result != EResultNoConnection {
+			t.Errorf("Result = %d, want %d", e.Result, EResultNoConnection)
+		}
+		typed++
+	})
+	r.subscribeRaw(CallbackIDSteamServersDisconnected, func(id CallbackID, data []byte) {
+		if id != CallbackIDSteamServersDisconnected {
+			t.Errorf("raw callback ID = %d, want %d", id, CallbackIDSteamServersDisconnected)
+		}
+		raw = append(raw, len(data))
+	})
+	r.subscribe(CallbackIDSteamShutdown, func(Callback) { other++ })
+
+	b := make([]byte, 4)
+	binary.LittleEndian.PutUint32(b, uint32(EResultNoConnection))
+	r.dispatch(CallbackIDSteamServersDisconnected, b)
+
+	// 数据不足时不调用类型化订阅者，原始订阅者仍然收到
+	r.dispatch(CallbackIDSteamServersDisconnected, b[:2])
+
+	// 重复取消是安全的
+	cancel()
+	cancel()
+	r.dispatch(CallbackIDSteamServersDisconnected, b)
+
+	if typed != 1 {
+		t.Errorf("typed handler called %d times, want 1", typed)
+	}
+	if len(raw) != 3 || raw[0] != 4 || raw[1] != 2 || raw[2] != 4 {
+		t.Errorf("raw handler data sizes = %v, want [4 2 4]", raw)
+	}
+	if other != 0 {
+		t.Errorf("unrelated handler called %d times, want 0", other)
+	}
+}
+
+func TestCallbackRegistry_UnsubscribeDuringDispatch(t *testing.T) {
+	r := newCallbackRegistry()
+	r.register(CallbackIDSteamShutdown, 0, func([]byte) Callback { return SteamShutdown{} })
+
+	calls := 0
+	var cancel func()
+	cancel = r.subscribe(CallbackIDSteamShutdown, func(Callback) {
+		calls++
+		cancel()
+	})
+	r.subscribe(CallbackIDSteamShutdown, func(Callback) { calls++ })
+
+	r.dispatch(CallbackIDSteamShutdown, nil)
+	r.dispatch(CallbackIDSteamShutdown, nil)
+
+	if calls != 3 {
+		t.Errorf("calls = %d, want 3", calls)
+	}
+}
+
+func TestBuiltinDecoders(t *testing.T) {
+	decode := func(id CallbackID, b []byte) Callback {
+		t.Helper()
+		entry, ok := globalCallbackRegistry.decoders[id]
+		if !ok {
+			t.Fatalf("no decoder registered for %d", id)
+		}
+		if len(b) < entry.size {
+			t.Fatalf("test data for %d is %d bytes, decoder needs %d", id, len(b), entry.size)
+		}
+		cb := entry.decode(b)
+		if cb.CallbackID() != id {
+			t.Errorf("decoded CallbackID() = %d, want %d", cb.CallbackID(), id)
+		}
+		return cb
+	}
+
+	failure := make([]byte, 8)
+	binary.LittleEndian.PutUint32(failure, uint32(EResultTimeout))
+	failure[4] = 1
+	if e := decode(CallbackIDSteamServerConnectFailure, failure).(SteamServerConnectFailure); e.Result != EResultTimeout || !e.StillRetrying {
+		t.Errorf("SteamServerConnectFailure = %+v", e)
+	}
+
+	overlay := make([]byte, 12)
+	overlay[0] = 1
+	binary.LittleEndian.PutUint32(overlay[4:], 480)
+	binary.LittleEndian.PutUint32(overlay[8:], 1234)
+	if e := decode(CallbackIDGameOverlayActivated, overlay).(GameOverlayActivated); !e.Active || e.UserInitiated || e.AppID != 480 || e.OverlayPID != 1234 {
+		t.Errorf("GameOverlayActivated = %+v", e)
+	}
+
+	completed := make([]byte, 16)
+	binary.LittleEndian.PutUint64(completed[0:], 0x1122334455667788)
+	binary.LittleEndian.PutUint32(completed[8:], 1111)
+	binary.LittleEndian.PutUint32(completed[12:], 24)
+	if e := decode(CallbackIDSteamAPICallCompleted, completed).(SteamAPICallCompleted); e.Call != 0x1122334455667788 || e.Callback != 1111 || e.ParamSize != 24 {
+		t.Errorf("SteamAPICallCompleted = %+v", e)
+	}
+
+	if e := decode(CallbackIDLowBatteryPower, []byte{7}).(LowBatteryPower); e.MinutesBatteryLeft != 7 {
+		t.Errorf("LowBatteryPower = %+v", e)
+	}
+
+	for _, id := range []CallbackID{CallbackIDSteamServersConnected, CallbackIDIPCountry, CallbackIDSteamShutdown} {
+		decode(id, nil)
+	}
+}
+
+func TestRegisteredCallbacks(t *testing.T) {
+	ids := RegisteredCallbacks()
+	if len(ids) == 0 {
+		t.Fatal("RegisteredCallbacks() returned no IDs")
+	}
+	for i := 1; i < len(ids); i++ {
+		if ids[i-1] >= ids[i] {
+			t.Fatalf("RegisteredCallbacks() not sorted: %v", ids)
+		}
+	}
+
+	// 与 On 的文档和 README 中列出的内置类型化事件保持一致
+	want := []CallbackID{
+		CallbackIDSteamServersConnected,
+		CallbackIDSteamServerConnectFailure,
+		CallbackIDSteamServersDisconnected,
+		CallbackIDEncryptedAppTicketResponse,
+		CallbackIDPersonaStateChange,
+		CallbackIDGameOverlayActivated,
+		CallbackIDIPCountry,
+		CallbackIDLowBatteryPower,
+		CallbackIDSteamAPICallCompleted,
+		CallbackIDSteamShutdown,
+	}
+	if !slices.Equal(ids, want) {
+		t.Errorf("RegisteredCallbacks() = %v, want %v", ids, want)
+	}
+}
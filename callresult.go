@@ -0,0 +1,195 @@
+package steamkit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/guowei-gong/steamkit-go/internal/purego"
+)
+
+// APICall 是异步调用句柄（SteamAPICall_t）
+type APICall uint64
+
+// InvalidAPICall 是无效的异步调用句柄（k_uAPICallInvalid）
+const InvalidAPICall APICall = 0
+
+// CallResult 是一个异步调用的结果，类似 future
+// T 是结果结构体对应的类型化事件，其回调 ID 必须已通过 RegisterCallback 注册解码器。
+// 结果在 RunCallbacks 处理 SteamAPICallCompleted_t 时送达。
+type CallResult[T Callback] struct {
+	call APICall
+	done chan struct{}
+	once sync.Once
+
+	result T
+	err    error
+}
+
+// NewCallResult 为异步调用句柄创建 CallResult
+// 句柄无效时返回的 CallResult 立即以 ErrInvalidAPICall 完成
+func NewCallResult[T Callback](call APICall) *CallResult[T] {
+	r := &CallResult[T]{
+		call: call,
+		done: make(chan struct{}),
+	}
+
+	if call == InvalidAPICall {
+		r.complete(nil, ErrInvalidAPICall)
+		return r
+	}
+
+	var zero T
+	globalCallResults.add(call, zero.CallbackID(), r.complete)
+	return r
+}
+
+// failedCallResult 返回已经以 err 完成的 CallResult
+func failedCallResult[T Callback](err error) *CallResult[T] {
+	r := &CallResult[T]{done: make(chan struct{})}
+	r.complete(nil, err)
+	return r
+}
+
+// Call 返回异步调用句柄
+func (r *CallResult[T]) Call() APICall {
+	return r.call
+}
+
+// Done 返回在调用完成（或被取消）时关闭的 channel
+func (r *CallResult[T]) Done() <-chan struct{} {
+	return r.done
+}
+
+// Await 等待调用完成，返回解码后的结果
+// ctx 被取消时放弃该调用并返回 ctx.Err()，之后到达的结果会被丢弃
+func (r *CallResult[T]) Await(ctx context.Context) (T, error) {
+	select {
+	case <-r.done:
+	case <-ctx.Done():
+		r.cancel(ctx.Err())
+	}
+	return r.result, r.err
+}
+
+// Cancel 放弃该调用，Await 返回 context.Canceled
+func (r *CallResult[T]) Cancel() {
+	r.cancel(context.Canceled)
+}
+
+// cancel 以 err 完成调用并停止等待结果
+func (r *CallResult[T]) cancel(err error) {
+	globalCallResults.remove(r.call)
+	r.complete(nil, err)
+}
+
+// complete 设置调用结果，只有第一次调用生效
+func (r *CallResult[T]) complete(cb Callback, err error) {
+	r.once.Do(func() {
+		if err == nil {
+			if result, ok := cb.(T); ok {
+				r.result = result
+			} else {
+				err = fmt.Errorf("steamkit: call %d completed with unexpected result type %T", r.call, cb)
+			}
+		}
+		r.err = err
+		close(r.done)
+	})
+}
+
+// pendingCallResult 是等待完成的异步调用
+type pendingCallResult struct {
+	id       CallbackID
+	complete func(Callback, error)
+}
+
+// callResultRegistry 跟踪所有等待完成的异步调用
+type callResultRegistry struct {
+	mu      sync.Mutex
+	pending map[APICall]pendingCallResult
+}
+
+var (
+	globalCallResults = &callResultRegistry{
+		pending: make(map[APICall]pendingCallResult),
+	}
+)
+
+// getAPICallResult 获取异步调用的结果结构体，测试时可替换
+var getAPICallResult = purego.CallManualDispatchGetAPICallResult
+
+// add 登记等待完成的异步调用
+func (c *callResultRegistry) add(call APICall, id CallbackID, complete func(Callback, error)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pending[call] = pendingCallResult{id: id, complete: complete}
+}
+
+// remove 取消登记
+func (c *callResultRegistry) remove(call APICall) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.pending, call)
+}
+
+// take 取出并取消登记异步调用
+func (c *callResultRegistry) take(call APICall) (pendingCallResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	p, ok := c.pending[call]
+	if ok {
+		delete(c.pending, call)
+	}
+	return p, ok
+}
+
+// failAll 以 err 完成所有等待中的异步调用（Shutdown 时调用）
+func (c *callResultRegistry) failAll(err error) {
+	c.mu.Lock()
+	pending := c.pending
+	c.pending = make(map[APICall]pendingCallResult)
+	c.mu.Unlock()
+
+	for _, p := range pending {
+		p.complete(nil, err)
+	}
+}
+
+// handleCallCompleted 处理 SteamAPICallCompleted_t，取回结果并完成对应的 CallResult
+// 必须在 FreeLastCallback 之前调用
+func (c *callResultRegistry) handleCallCompleted(pipe int32, completed SteamAPICallCompleted) {
+	p, ok := c.take(APICall(completed.Call))
+	if !ok {
+		return
+	}
+	p.complete(c.fetch(pipe, completed, p.id))
+}
+
+// fetch 取回并解码异步调用的结果
+func (c *callResultRegistry) fetch(pipe int32, completed SteamAPICallCompleted, id CallbackID) (Callback, error) {
+	if completed.Callback != id {
+		return nil, fmt.Errorf("steamkit: call %d completed with callback %d, want %d", completed.Call, completed.Callback, id)
+	}
+
+	globalCallbackRegistry.mu.RLock()
+	entry, known := globalCallbackRegistry.decoders[id]
+	globalCallbackRegistry.mu.RUnlock()
+	if !known {
+		return nil, fmt.Errorf("steamkit: no decoder registered for callback %d", id)
+	}
+
+	buf := make([]byte, completed.ParamSize)
+	ok, failed := getAPICallResult(pipe, completed.Call, buf, int32(id))
+	if failed {
+		return nil, fmt.Errorf("%w: call %d", ErrIOFailure, completed.Call)
+	}
+	if !ok {
+		return nil, fmt.Errorf("steamkit: failed to get result of call %d", completed.Call)
+	}
+	if len(buf) < entry.size {
+		return nil, fmt.Errorf("steamkit: result of call %d is %d bytes, want at least %d", completed.Call, len(buf), entry.size)
+	}
+
+	return entry.decode(buf), nil
+}
@@ -0,0 +1,122 @@
+package steamkit
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"testing"
+	"time"
+)
+
+// stubGetAPICallResult 替换取回异步调用结果的函数
+func stubGetAPICallResult(t *testing.T, fn func(call uint64, buf []byte, id int32) (bool, bool)) {
+	t.Helper()
+	orig := getAPICallResult
+	getAPICallResult = func(pipe int32, call uint64, buf []byte, id int32) (bool, bool) {
+		return fn(call, buf, id)
+	}
+	t.Cleanup(func() { getAPICallResult = orig })
+}
+
+func completedFor(call APICall, id CallbackID, size uint32) SteamAPICallCompleted {
+	return SteamAPICallCompleted{Call: uint64(call), Callback: id, ParamSize: size}
+}
+
+func TestCallResult_Await(t *testing.T) {
+	stubGetAPICallResult(t, func(call uint64, buf []byte, id int32) (bool, bool) {
+		if call != 42 || CallbackID(id) != CallbackIDEncryptedAppTicketResponse {
+			t.Errorf("getAPICallResult(%d, %d), want (42, %d)", call, id, CallbackIDEncryptedAppTicketResponse)
+		}
+		binary.LittleEndian.PutUint32(buf, uint32(EResultOK))
+		return true, false
+	})
+
+	r := NewCallResult[EncryptedAppTicketResponse](APICall(42))
+	if r.Call() != APICall(42) {
+		t.Errorf("Call() = %d, want 42", r.Call())
+	}
+
+	// 其他调用的完成不影响该结果
+	globalCallResults.handleCallCompleted(0, completedFor(7, CallbackIDEncryptedAppTicketResponse, 4))
+	select {
+	case <-r.Done():
+		t.Fatal("CallResult completed by unrelated call")
+	default:
+	}
+
+	globalCallResults.handleCallCompleted(0, completedFor(42, CallbackIDEncryptedAppTicketResponse, 4))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	res, err := r.Await(ctx)
+	if err != nil {
+		t.Fatalf("Await() error = %v", err)
+	}
+	if res.Result != EResultOK {
+		t.Errorf("Result = %d, want %d", res.Result, EResultOK)
+	}
+}
+
+func TestCallResult_IOFailure(t *testing.T) {
+	stubGetAPICallResult(t, func(uint64, []byte, int32) (bool, bool) {
+		return false, true
+	})
+
+	r := NewCallResult[EncryptedAppTicketResponse](APICall(43))
+	globalCallResults.handleCallCompleted(0, completedFor(43, CallbackIDEncryptedAppTicketResponse, 4))
+
+	if _, err := r.Await(context.Background()); !errors.Is(err, ErrIOFailure) {
+		t.Errorf("Await() error = %v, want ErrIOFailure", err)
+	}
+}
+
+func TestCallResult_UnexpectedCallback(t *testing.T) {
+	stubGetAPICallResult(t, func(uint64, []byte, int32) (bool, bool) {
+		t.Error("getAPICallResult should not be called for mismatched callback")
+		return false, false
+	})
+
+	r := NewCallResult[EncryptedAppTicketResponse](APICall(44))
+	globalCallResults.handleCallCompleted(0, completedFor(44, CallbackIDPersonaStateChange, 12))
+
+	if _, err := r.Await(context.Background()); err == nil {
+		t.Error("Await() error = nil, want mismatch error")
+	}
+}
+
+func TestCallResult_ContextCanceled(t *testing.T) {
+	stubGetAPICallResult(t, func(uint64, []byte, int32) (bool, bool) {
+		t.Error("getAPICallResult should not be called for canceled call")
+		return false, false
+	})
+
+	r := NewCallResult[EncryptedAppTicketResponse](APICall(45))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := r.Await(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Await() error = %v, want context.Canceled", err)
+	}
+
+	// 取消后到达的结果被丢弃
+	globalCallResults.handleCallCompleted(0, completedFor(45, CallbackIDEncryptedAppTicketResponse, 4))
+	if _, err := r.Await(context.Background()); !errors.Is(err, context.Canceled) {
+		t.Errorf("Await() after cancel error = %v, want context.Canceled", err)
+	}
+}
+
+func TestCallResult_Invalid(t *testing.T) {
+	r := NewCallResult[EncryptedAppTicketResponse](InvalidAPICall)
+	if _, err := r.Await(context.Background()); !errors.Is(err, ErrInvalidAPICall) {
+		t.Errorf("Await() error = %v, want ErrInvalidAPICall", err)
+	}
+}
+
+func TestCallResult_FailAll(t *testing.T) {
+	r := NewCallResult[EncryptedAppTicketResponse](APICall(46))
+	globalCallResults.failAll(ErrShutdown)
+
+	if _, err := r.Await(context.Background()); !errors.Is(err, ErrShutdown) {
+		t.Errorf("Await() error = %v, want ErrShutdown", err)
+	}
+}
@@ -0,0 +1,96 @@
+package steamkit
+
+import (
+	"sort"
+
+	"github.com/guowei-gong/steamkit-go/internal/purego"
+)
+
+// ErrUnsupported 表示加载的 Steam 库中没有所需的函数
+// 返回的错误是 *UnsupportedError，可以通过 errors.Is(err, ErrUnsupported) 判断
+var ErrUnsupported = purego.ErrUnsupported
+
+// UnsupportedError 表示调用了加载的 Steam 库中不存在的函数
+type UnsupportedError = purego.UnsupportedError
+
+// manualDispatchSymbols 是手动回调分发所需的函数
+var manualDispatchSymbols = []string{
+	"SteamAPI_GetHSteamPipe",
+	"SteamAPI_ManualDispatch_Init",
+	"SteamAPI_ManualDispatch_RunFrame",
+	"SteamAPI_ManualDispatch_GetNextCallback",
+	"SteamAPI_ManualDispatch_FreeLastCallback",
+	"SteamAPI_ManualDispatch_GetAPICallResult",
+}
+
+// InterfaceCapability 描述一个接口在加载的库中的可用情况
+type InterfaceCapability struct {
+	Name      string   // 接口名，如 "ISteamNetworkingSockets"
+	Version   string   // 协商选定的接口版本，没有访问函数的接口为空
+	Available []string // 可用的方法
+	Missing   []string // 缺失的方法
+}
+
+// CapabilityReport 是加载的 Steam 库的能力报告
+type CapabilityReport struct {
+	Interfaces []InterfaceCapability // 按接口名排序
+}
+
+// Capabilities 返回加载的 Steam 库中各接口和方法的可用情况
+// 未初始化时返回空报告
+func Capabilities() CapabilityReport {
+	versions := make(map[string]string)
+	for _, v := range purego.SelectedInterfaceVersions() {
+		versions["I"+v.Interface] = v.Version
+	}
+
+	byName := make(map[string]*InterfaceCapability)
+	var names []string
+	for _, sym := range purego.Symbols() {
+		iface, ok := byName[sym.Interface]
+		if !ok {
+			iface = &InterfaceCapability{Name: sym.Interface, Version: versions[sym.Interface]}
+			byName[sym.Interface] = iface
+			names = append(names, sym.Interface)
+		}
+		if sym.Available {
+			iface.Available = append(iface.Available, sym.Method)
+		} else {
+			iface.Missing = append(iface.Missing, sym.Method)
+		}
+	}
+
+	sort.Strings(names)
+	report := CapabilityReport{Interfaces: make([]InterfaceCapability, 0, len(names))}
+	for _, name := range names {
+		report.Interfaces = append(report.Interfaces, *byName[name])
+	}
+	return report
+}
+
+// Supports 检查接口的方法是否可用，如 Supports("ISteamNetworkingSockets", "ConfigureConnectionLanes")
+func (r CapabilityReport) Supports(iface, method string) bool {
+	for _, i := range r.Interfaces {
+		if i.Name != iface {
+			continue
+		}
+		for _, m := range i.Available {
+			if m == method {
+				return true
+			}
+		}
+		return false
+	}
+	return false
+}
+
+// Missing 返回所有缺失的方法，格式为 "接口.方法"
+func (r CapabilityReport) Missing() []string {
+	var missing []string
+	for _, i := range r.Interfaces {
+		for _, m := range i.Missing {
+			missing = append(missing, i.Name+"."+m)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,45 @@
+package steamkit
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestCapabilityReport(t *testing.T) {
+	report := CapabilityReport{Interfaces: []InterfaceCapability{
+		{Name: "ISteamNetworkingSockets", Version: "SteamNetworkingSockets011", Available: []string{"CreatePollGroup"}, Missing: []string{"ConfigureConnectionLanes"}},
+		{Name: "ISteamUser", Version: "SteamUser023", Available: []string{"GetSteamID"}},
+	}}
+
+	if !report.Supports("ISteamNetworkingSockets", "CreatePollGroup") {
+		t.Error("Supports(CreatePollGroup) = false, want true")
+	}
+	if report.Supports("ISteamNetworkingSockets", "ConfigureConnectionLanes") {
+		t.Error("Supports(ConfigureConnectionLanes) = true, want false")
+	}
+	if report.Supports("ISteamFriends", "GetPersonaName") {
+		t.Error("Supports() for unknown interface = true, want false")
+	}
+
+	missing := report.Missing()
+	if len(missing) != 1 || missing[0] != "ISteamNetworkingSockets.ConfigureConnectionLanes" {
+		t.Errorf("Missing() = %v", missing)
+	}
+}
+
+func TestUnsupportedError(t *testing.T) {
+	var err error = &UnsupportedError{Symbol: "SteamAPI_ISteamUser_GetEncryptedAppTicket"}
+	if !errors.Is(err, ErrUnsupported) {
+		t.Errorf("errors.Is(%v, ErrUnsupported) = false", err)
+	}
+}
+
+func TestRequestEncryptedAppTicket_NotInitialized(t *testing.T) {
+	if _, err := RequestEncryptedAppTicket(nil).Await(context.Background()); !errors.Is(err, ErrNotInitialized) {
+		t.Errorf("Await() error = %v, want ErrNotInitialized", err)
+	}
+	if _, err := GetEncryptedAppTicket(); !errors.Is(err, ErrNotInitialized) {
+		t.Errorf("GetEncryptedAppTicket() error = %v, want ErrNotInitialized", err)
+	}
+}
@@ -0,0 +1,295 @@
+package steamkit
+
+import "fmt"
+
+// EResult 是 Steamworks 通用的结果码
+// EResult 实现了 error 接口，可以直接作为哨兵错误与 errors.Is 配合使用：
+//
+//	if errors.Is(err, steamkit.EResultLimitExceeded) { ... }
+type EResult int32
+
+const (
+	EResultNone                                    EResult = 0   // 未设置结果
+	EResultOK                                      EResult = 1   // 成功
+	EResultFail                                    EResult = 2   // 通用失败
+	EResultNoConnection                            EResult = 3   // 没有连接到 Steam
+	EResultInvalidPassword                         EResult = 5   // 密码或票据无效
+	EResultLoggedInElsewhere                       EResult = 6   // 同一用户在其他地方登录
+	EResultInvalidProtocolVer                      EResult = 7   // 协议版本不正确
+	EResultInvalidParam                            EResult = 8   // 参数不正确
+	EResultFileNotFound                            EResult = 9   // 文件未找到
+	EResultBusy                                    EResult = 10  // 被调用的方法正忙
+	EResultInvalidState                            EResult = 11  // 被调用的对象处于无效状态
+	EResultInvalidName                             EResult = 12  // 名称无效
+	EResultInvalidEmail                            EResult = 13  // 邮箱无效
+	EResultDuplicateName                           EResult = 14  // 名称重复
+	EResultAccessDenied                            EResult = 15  // 访问被拒绝
+	EResultTimeout                                 EResult = 16  // 操作超时
+	EResultBanned                                  EResult = 17  // 被 VAC2 封禁
+	EResultAccountNotFound                         EResult = 18  // 账号不存在
+	EResultInvalidSteamID                          EResult = 19  // SteamID 无效
+	EResultServiceUnavailable                      EResult = 20  // 服务当前不可用
+	EResultNotLoggedOn                             EResult = 21  // 用户未登录
+	EResultPending                                 EResult = 22  // 请求正在处理中
+	EResultEncryptionFailure                       EResult = 23  // 加密或解密失败
+	EResultInsufficientPrivilege                   EResult = 24  // 权限不足
+	EResultLimitExceeded                           EResult = 25  // 超出限制
+	EResultRevoked                                 EResult = 26  // 访问已被撤销
+	EResultExpired                                 EResult = 27  // 许可证或访客通行证已过期
+	EResultAlreadyRedeemed                         EResult = 28  // 访客通行证已被兑换
+	EResultDuplicateRequest                        EResult = 29  // 重复的请求
+	EResultAlreadyOwned                            EResult = 30  // 已经拥有
+	EResultIPNotFound                              EResult = 31  // IP 地址未找到
+	EResultPersistFailed                           EResult = 32  // 写入失败
+	EResultLockingFailed                           EResult = 33  // 获取锁失败
+	EResultLogonSessionReplaced                    EResult = 34  // 登录会话被替换
+	EResultConnectFailed                           EResult = 35  // 连接失败
+	EResultHandshakeFailed                         EResult = 36  // 认证握手失败
+	EResultIOFailure                               EResult = 37  // 通用 IO 失败
+	EResultRemoteDisconnect                        EResult = 38  // 远程服务器断开连接
+	EResultShoppingCartNotFound                    EResult = 39  // 购物车不存在
+	EResultBlocked                                 EResult = 40  // 被屏蔽
+	EResultIgnored                                 EResult = 41  // 被忽略
+	EResultNoMatch                                 EResult = 42  // 没有匹配项
+	EResultAccountDisabled                         EResult = 43  // 账号已停用
+	EResultServiceReadOnly                         EResult = 44  // 服务当前只读
+	EResultAccountNotFeatured                      EResult = 45  // 账号没有该功能
+	EResultAdministratorOK                         EResult = 46  // 以管理员身份允许
+	EResultContentVersion                          EResult = 47  // 内容版本不匹配
+	EResultTryAnotherCM                            EResult = 48  // 当前 CM 无法服务，需要尝试其他 CM
+	EResultPasswordRequiredToKickSession           EResult = 49  // 需要密码才能踢出其他会话
+	EResultAlreadyLoggedInElsewhere                EResult = 50  // 已在其他地方登录
+	EResultSuspended                               EResult = 51  // 长时间运行的操作被挂起
+	EResultCancelled                               EResult = 52  // 操作被取消
+	EResultDataCorruption                          EResult = 53  // 数据损坏
+	EResultDiskFull                                EResult = 54  // 磁盘已满
+	EResultRemoteCallFailed                        EResult = 55  // 远程调用失败
+	EResultPasswordUnset                           EResult = 56  // 未设置密码
+	EResultExternalAccountUnlinked                 EResult = 57  // 外部账号未关联
+	EResultPSNTicketInvalid                        EResult = 58  // PSN 票据无效
+	EResultExternalAccountAlreadyLinked            EResult = 59  // 外部账号已关联
+	EResultRemoteFileConflict                      EResult = 60  // 远程文件冲突
+	EResultIllegalPassword                         EResult = 61  // 密码不合规
+	EResultSameAsPreviousValue                     EResult = 62  // 与之前的值相同
+	EResultAccountLogonDenied                      EResult = 63  // 账号登录被拒绝（需要邮箱验证码）
+	EResultCannotUseOldPassword                    EResult = 64  // 不能使用旧密码
+	EResultInvalidLoginAuthCode                    EResult = 65  // 登录验证码无效
+	EResultAccountLogonDeniedNoMail                EResult = 66  // 账号登录被拒绝（无法发送邮件）
+	EResultHardwareNotCapableOfIPT                 EResult = 67  // 硬件不支持 IPT
+	EResultIPTInitError                            EResult = 68  // IPT 初始化失败
+	EResultParentalControlRestricted               EResult = 69  // 家长控制限制
+	EResultFacebookQueryError                      EResult = 70  // Facebook 查询失败
+	EResultExpiredLoginAuthCode                    EResult = 71  // 登录验证码已过期
+	EResultIPLoginRestrictionFailed                EResult = 72  // IP 登录限制
+	EResultAccountLockedDown                       EResult = 73  // 账号已锁定
+	EResultAccountLogonDeniedVerifiedEmailRequired EResult = 74  // 需要验证邮箱才能登录
+	EResultNoMatchingURL                           EResult = 75  // 没有匹配的 URL
+	EResultBadResponse                             EResult = 76  // 响应无效
+	EResultRequirePasswordReEntry                  EResult = 77  // 需要重新输入密码
+	EResultValueOutOfRange                         EResult = 78  // 值超出范围
+	EResultUnexpectedError                         EResult = 79  // 意外错误
+	EResultDisabled                                EResult = 80  // 功能已禁用
+	EResultInvalidCEGSubmission                    EResult = 81  // CEG 提交无效
+	EResultRestrictedDevice                        EResult = 82  // 设备受限
+	EResultRegionLocked                            EResult = 83  // 区域锁定
+	EResultRateLimitExceeded                       EResult = 84  // 超出频率限制
+	EResultAccountLoginDeniedNeedTwoFactor         EResult = 85  // 需要两步验证
+	EResultItemDeleted                             EResult = 86  // 物品已删除
+	EResultAccountLoginDeniedThrottle              EResult = 87  // 登录尝试过于频繁
+	EResultTwoFactorCodeMismatch                   EResult = 88  // 两步验证码不匹配
+	EResultTwoFactorActivationCodeMismatch         EResult = 89  // 两步验证激活码不匹配
+	EResultAccountAssociatedToMultiplePartners     EResult = 90  // 账号关联了多个合作方
+	EResultNotModified                             EResult = 91  // 数据未修改
+	EResultNoMobileDevice                          EResult = 92  // 没有关联移动设备
+	EResultTimeNotSynced                           EResult = 93  // 时间未同步
+	EResultSmsCodeFailed                           EResult = 94  // 短信验证码失败
+	EResultAccountLimitExceeded                    EResult = 95  // 超出账号数量限制
+	EResultAccountActivityLimitExceeded            EResult = 96  // 超出账号活动限制
+	EResultPhoneActivityLimitExceeded              EResult = 97  // 超出手机活动限制
+	EResultRefundToWallet                          EResult = 98  // 退款到钱包
+	EResultEmailSendFailure                        EResult = 99  // 邮件发送失败
+	EResultNotSettled                              EResult = 100 // 付款尚未结算
+	EResultNeedCaptcha                             EResult = 101 // 需要验证码
+	EResultGSLTDenied                              EResult = 102 // 游戏服务器登录令牌被拒绝
+	EResultGSOwnerDenied                           EResult = 103 // 游戏服务器所有者被拒绝
+	EResultInvalidItemType                         EResult = 104 // 物品类型无效
+	EResultIPBanned                                EResult = 105 // IP 被封禁
+	EResultGSLTExpired                             EResult = 106 // 游戏服务器登录令牌已过期
+	EResultInsufficientFunds                       EResult = 107 // 余额不足
+	EResultTooManyPending                          EResult = 108 // 待处理的请求过多
+	EResultNoSiteLicensesFound                     EResult = 109 // 没有站点许可证
+	EResultWGNetworkSendExceeded                   EResult = 110 // 超出 WG 网络发送限制
+	EResultAccountNotFriends                       EResult = 111 // 不是好友
+	EResultLimitedUserAccount                      EResult = 112 // 受限用户账号
+	EResultCantRemoveItem                          EResult = 113 // 无法移除物品
+	EResultAccountDeleted                          EResult = 114 // 账号已删除
+	EResultExistingUserCancelledLicense            EResult = 115 // 用户已取消许可证
+	EResultCommunityCooldown                       EResult = 116 // 社区冷却中
+	EResultNoLauncherSpecified                     EResult = 117 // 未指定启动器
+	EResultMustAgreeToSSA                          EResult = 118 // 必须同意 Steam 订户协议
+	EResultLauncherMigrated                        EResult = 119 // 启动器已迁移
+	EResultSteamRealmMismatch                      EResult = 120 // Steam 领域不匹配
+	EResultInvalidSignature                        EResult = 121 // 签名无效
+	EResultParseFailure                            EResult = 122 // 解析失败
+	EResultNoVerifiedPhone                         EResult = 123 // 没有已验证的手机
+	EResultInsufficientBattery                     EResult = 124 // 电量不足
+	EResultChargerRequired                         EResult = 125 // 需要连接充电器
+	EResultCachedCredentialInvalid                 EResult = 126 // 缓存的凭据无效
+	EResultPhoneNumberIsVOIP                       EResult = 127 // 手机号码为 VOIP 号码
+	EResultNotSupported                            EResult = 128 // 不支持该操作
+	EResultFamilySizeLimitExceeded                 EResult = 129 // 超出家庭组人数限制
+	EResultOfflineAppCacheInvalid                  EResult = 130 // 离线应用缓存无效
+)
+
+// eresultNames 是各结果码的名称
+var eresultNames = map[EResult]string{
+	EResultNone:                                    "None",
+	EResultOK:                                      "OK",
+	EResultFail:                                    "Fail",
+	EResultNoConnection:                            "NoConnection",
+	EResultInvalidPassword:                         "InvalidPassword",
+	EResultLoggedInElsewhere:                       "LoggedInElsewhere",
+	EResultInvalidProtocolVer:                      "InvalidProtocolVer",
+	EResultInvalidParam:                            "InvalidParam",
+	EResultFileNotFound:                            "FileNotFound",
+	EResultBusy:                                    "Busy",
+	EResultInvalidState:                            "InvalidState",
+	EResultInvalidName:                             "InvalidName",
+	EResultInvalidEmail:                            "InvalidEmail",
+	EResultDuplicateName:                           "DuplicateName",
+	EResultAccessDenied:                            "AccessDenied",
+	EResultTimeout:                                 "Timeout",
+	EResultBanned:                                  "Banned",
+	EResultAccountNotFound:                         "AccountNotFound",
+	EResultInvalidSteamID:                          "InvalidSteamID",
+	EResultServiceUnavailable:                      "ServiceUnavailable",
+	EResultNotLoggedOn:                             "NotLoggedOn",
+	EResultPending:                                 "Pending",
+	EResultEncryptionFailure:                       "EncryptionFailure",
+	EResultInsufficientPrivilege:                   "InsufficientPrivilege",
+	EResultLimitExceeded:                           "LimitExceeded",
+	EResultRevoked:                                 "Revoked",
+	EResultExpired:                                 "Expired",
+	EResultAlreadyRedeemed:                         "AlreadyRedeemed",
+	EResultDuplicateRequest:                        "DuplicateRequest",
+	EResultAlreadyOwned:                            "AlreadyOwned",
+	EResultIPNotFound:                              "IPNotFound",
+	EResultPersistFailed:                           "PersistFailed",
+	EResultLockingFailed:                           "LockingFailed",
+	EResultLogonSessionReplaced:                    "LogonSessionReplaced",
+	EResultConnectFailed:                           "ConnectFailed",
+	EResultHandshakeFailed:                         "HandshakeFailed",
+	EResultIOFailure:                               "IOFailure",
+	EResultRemoteDisconnect:                        "RemoteDisconnect",
+	EResultShoppingCartNotFound:                    "ShoppingCartNotFound",
+	EResultBlocked:                                 "Blocked",
+	EResultIgnored:                                 "Ignored",
+	EResultNoMatch:                                 "NoMatch",
+	EResultAccountDisabled:                         "AccountDisabled",
+	EResultServiceReadOnly:                         "ServiceReadOnly",
+	EResultAccountNotFeatured:                      "AccountNotFeatured",
+	EResultAdministratorOK:                         "AdministratorOK",
+	EResultContentVersion:                          "ContentVersion",
+	EResultTryAnotherCM:                            "TryAnotherCM",
+	EResultPasswordRequiredToKickSession:           "PasswordRequiredToKickSession",
+	EResultAlreadyLoggedInElsewhere:                "AlreadyLoggedInElsewhere",
+	EResultSuspended:                               "Suspended",
+	EResultCancelled:                               "Cancelled",
+	EResultDataCorruption:                          "DataCorruption",
+	EResultDiskFull:                                "DiskFull",
+	EResultRemoteCallFailed:                        "RemoteCallFailed",
+	EResultPasswordUnset:                           "PasswordUnset",
+	EResultExternalAccountUnlinked:                 "ExternalAccountUnlinked",
+	EResultPSNTicketInvalid:                        "PSNTicketInvalid",
+	EResultExternalAccountAlreadyLinked:            "ExternalAccountAlreadyLinked",
+	EResultRemoteFileConflict:                      "RemoteFileConflict",
+	EResultIllegalPassword:                         "IllegalPassword",
+	EResultSameAsPreviousValue:                     "SameAsPreviousValue",
+	EResultAccountLogonDenied:                      "AccountLogonDenied",
+	EResultCannotUseOldPassword:                    "CannotUseOldPassword",
+	EResultInvalidLoginAuthCode:                    "InvalidLoginAuthCode",
+	EResultAccountLogonDeniedNoMail:                "AccountLogonDeniedNoMail",
+	EResultHardwareNotCapableOfIPT:                 "HardwareNotCapableOfIPT",
+	EResultIPTInitError:                            "IPTInitError",
+	EResultParentalControlRestricted:               "ParentalControlRestricted",
+	EResultFacebookQueryError:                      "FacebookQueryError",
+	EResultExpiredLoginAuthCode:                    "ExpiredLoginAuthCode",
+	EResultIPLoginRestrictionFailed:                "IPLoginRestrictionFailed",
+	EResultAccountLockedDown:                       "AccountLockedDown",
+	EResultAccountLogonDeniedVerifiedEmailRequired: "AccountLogonDeniedVerifiedEmailRequired",
+	EResultNoMatchingURL:                           "NoMatchingURL",
+	EResultBadResponse:                             "BadResponse",
+	EResultRequirePasswordReEntry:                  "RequirePasswordReEntry",
+	EResultValueOutOfRange:                         "ValueOutOfRange",
+	EResultUnexpectedError:                         "UnexpectedError",
+	EResultDisabled:                                "Disabled",
+	EResultInvalidCEGSubmission:                    "InvalidCEGSubmission",
+	EResultRestrictedDevice:                        "RestrictedDevice",
+	EResultRegionLocked:                            "RegionLocked",
+	EResultRateLimitExceeded:                       "RateLimitExceeded",
+	EResultAccountLoginDeniedNeedTwoFactor:         "AccountLoginDeniedNeedTwoFactor",
+	EResultItemDeleted:                             "ItemDeleted",
+	EResultAccountLoginDeniedThrottle:              "AccountLoginDeniedThrottle",
+	EResultTwoFactorCodeMismatch:                   "TwoFactorCodeMismatch",
+	EResultTwoFactorActivationCodeMismatch:         "TwoFactorActivationCodeMismatch",
+	EResultAccountAssociatedToMultiplePartners:     "AccountAssociatedToMultiplePartners",
+	EResultNotModified:                             "NotModified",
+	EResultNoMobileDevice:                          "NoMobileDevice",
+	EResultTimeNotSynced:                           "TimeNotSynced",
+	EResultSmsCodeFailed:                           "SmsCodeFailed",
+	EResultAccountLimitExceeded:                    "AccountLimitExceeded",
+	EResultAccountActivityLimitExceeded:            "AccountActivityLimitExceeded",
+	EResultPhoneActivityLimitExceeded:              "PhoneActivityLimitExceeded",
+	EResultRefundToWallet:                          "RefundToWallet",
+	EResultEmailSendFailure:                        "EmailSendFailure",
+	EResultNotSettled:                              "NotSettled",
+	EResultNeedCaptcha:                             "NeedCaptcha",
+	EResultGSLTDenied:                              "GSLTDenied",
+	EResultGSOwnerDenied:                           "GSOwnerDenied",
+	EResultInvalidItemType:                         "InvalidItemType",
+	EResultIPBanned:                                "IPBanned",
+	EResultGSLTExpired:                             "GSLTExpired",
+	EResultInsufficientFunds:                       "InsufficientFunds",
+	EResultTooManyPending:                          "TooManyPending",
+	EResultNoSiteLicensesFound:                     "NoSiteLicensesFound",
+	EResultWGNetworkSendExceeded:                   "WGNetworkSendExceeded",
+	EResultAccountNotFriends:                       "AccountNotFriends",
+	EResultLimitedUserAccount:                      "LimitedUserAccount",
+	EResultCantRemoveItem:                          "CantRemoveItem",
+	EResultAccountDeleted:                          "AccountDeleted",
+	EResultExistingUserCancelledLicense:            "ExistingUserCancelledLicense",
+	EResultCommunityCooldown:                       "CommunityCooldown",
+	EResultNoLauncherSpecified:                     "NoLauncherSpecified",
+	EResultMustAgreeToSSA:                          "MustAgreeToSSA",
+	EResultLauncherMigrated:                        "LauncherMigrated",
+	EResultSteamRealmMismatch:                      "SteamRealmMismatch",
+	EResultInvalidSignature:                        "InvalidSignature",
+	EResultParseFailure:                            "ParseFailure",
+	EResultNoVerifiedPhone:                         "NoVerifiedPhone",
+	EResultInsufficientBattery:                     "InsufficientBattery",
+	EResultChargerRequired:                         "ChargerRequired",
+	EResultCachedCredentialInvalid:                 "CachedCredentialInvalid",
+	EResultPhoneNumberIsVOIP:                       "PhoneNumberIsVOIP",
+	EResultNotSupported:                            "NotSupported",
+	EResultFamilySizeLimitExceeded:                 "FamilySizeLimitExceeded",
+	EResultOfflineAppCacheInvalid:                  "OfflineAppCacheInvalid",
+}
+
+// String 返回结果码的名称
+func (r EResult) String() string {
+	if name, ok := eresultNames[r]; ok {
+		return name
+	}
+	return fmt.Sprintf("EResult(%d)", int32(r))
+}
+
+// Error 实现 error 接口
+func (r EResult) Error() string {
+	return fmt.Sprintf("steamkit: %s (EResult %d)", r.String(), int32(r))
+}
+
+// IsOK 检查结果是否为 EResultOK
+// Steamworks 中只有 EResultOK 表示成功，EResultNone 等其他值都是失败
+func (r EResult) IsOK() bool {
+	return r == EResultOK
+}
@@ -0,0 +1,56 @@
+package steamkit
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestEResult_String(t *testing.T) {
+	tests := []struct {
+		r    EResult
+		want string
+	}{
+		{EResultNone, "None"},
+		{EResultOK, "OK"},
+		{EResultLimitExceeded, "LimitExceeded"},
+		{EResultOfflineAppCacheInvalid, "OfflineAppCacheInvalid"},
+		{EResult(4), "EResult(4)"},
+		{EResult(-1), "EResult(-1)"},
+	}
+	for _, tt := range tests {
+		if got := tt.r.String(); got != tt.want {
+			t.Errorf("EResult(%d).String() = %q, want %q", int32(tt.r), got, tt.want)
+		}
+	}
+}
+
+func TestEResult_Error(t *testing.T) {
+	// 只有 OK 表示成功，0 也是失败
+	if !EResultOK.IsOK() || EResultNone.IsOK() {
+		t.Error("IsOK() should only accept EResultOK")
+	}
+
+	var err error = EResultLimitExceeded
+	if want := "steamkit: LimitExceeded (EResult 25)"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+
+	wrapped := fmt.Errorf("send: %w", err)
+	if !errors.Is(wrapped, EResultLimitExceeded) {
+		t.Error("errors.Is(err, EResultLimitExceeded) = false")
+	}
+	if errors.Is(wrapped, EResultNoConnection) {
+		t.Error("errors.Is(err, EResultNoConnection) = true")
+	}
+}
+
+func TestEResult_Names(t *testing.T) {
+	seen := make(map[string]EResult)
+	for r, name := range eresultNames {
+		if other, ok := seen[name]; ok {
+			t.Errorf("EResult %d and %d share name %q", r, other, name)
+		}
+		seen[name] = r
+	}
+}
@@ -0,0 +1,19 @@
+package steamkit
+
+import (
+	"errors"
+)
+
+var (
+	// ErrInvalidAPICall 表示异步调用句柄无效（k_uAPICallInvalid），通常是调用本身失败
+	ErrInvalidAPICall = errors.New("steamkit: invalid API call handle")
+
+	// ErrIOFailure 表示异步调用因网络或 IO 问题失败（GetAPICallResult 的 pbFailed）
+	ErrIOFailure = errors.New("steamkit: API call IO failure")
+
+	// ErrShutdown 表示 Steam API 已关闭，未完成的异步调用不会再有结果
+	ErrShutdown = errors.New("steamkit: Steam API shut down")
+
+	// ErrInvalidSteamID 表示无法解析的 SteamID 字符串
+	ErrInvalidSteamID = errors.New("steamkit: invalid SteamID")
+)
@@ -21,7 +21,10 @@ func main() {
 	fmt.Println("✓ Steam API 初始化成功")
 
 	// 获取 SteamID
-	steamID := steamkit.GetSteamID()
+	steamID, err := steamkit.GetSteamID()
+	if err != nil {
+		log.Fatalf("无法获取 SteamID: %v", err)
+	}
 	if steamID == 0 {
 		log.Fatal("无法获取 SteamID（可能未登录 Steam）")
 	}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"time"
@@ -17,25 +18,29 @@ func main() {
 	defer steamkit.Shutdown()
 
 	// 获取当前用户 SteamID
-	steamID := steamkit.GetSteamID()
+	steamID, err := steamkit.GetSteamID()
+	if err != nil {
+		log.Fatalf("无法获取 SteamID: %v", err)
+	}
 	fmt.Printf("当前用户 SteamID: %d\n", steamID)
 
 	// 设置全局连接状态变化回调
+	// 回调在 GetSockets 时注册到原生层，在回调循环的每一帧中触发
 	steamnet.SetConnectionStatusChangedCallback(func(info *steamnet.ConnectionStatusChangedInfo) {
 		fmt.Printf("\n[全局回调] 连接状态变化:\n")
 		fmt.Printf("  连接: %d\n", info.Connection)
 		fmt.Printf("  旧状态: %s\n", info.OldState)
 		fmt.Printf("  新状态: %s\n", info.NewState)
-		if info.EndReason != 0 {
-			fmt.Printf("  结束原因: %d\n", info.EndReason)
+		if info.EndReason != steamnet.EndReasonInvalid {
+			fmt.Printf("  结束原因: %s (%d)\n", info.EndReason, info.EndReason)
 			fmt.Printf("  调试信息: %s\n", info.EndDebug)
 		}
 	})
 
 	// 获取 ISteamNetworkingSockets 接口
-	sockets := steamnet.GetSockets()
-	if sockets == nil {
-		log.Fatal("无法获取 ISteamNetworkingSockets 接口")
+	sockets, err := steamnet.GetSockets()
+	if err != nil {
+		log.Fatalf("无法获取 ISteamNetworkingSockets 接口: %v", err)
 	}
 
 	// 创建监听套接字
@@ -49,7 +54,7 @@ func main() {
 
 	// 模拟连接到远程对等方
 	fmt.Println("\n连接到远程对等方...")
-	remoteSteamID := uint64(76561198000000000) // 示例 SteamID
+	remoteSteamID := steamkit.SteamID(76561198000000000) // 示例 SteamID
 	identity := steamnet.NewIdentityFromSteamID(remoteSteamID)
 
 	conn, err := sockets.ConnectP2P(identity, 0, nil)
@@ -100,21 +105,23 @@ func main() {
 		log.Printf("刷新消息失败: %v", err)
 	}
 
-	// 运行回调处理循环
+	// 启动后台回调循环，每帧分发 Steam 回调和网络回调（连接状态变化回调在循环中触发）
 	fmt.Println("\n运行回调处理循环（5 秒）...")
 	fmt.Println("注意：实际的回调需要 Steam 客户端运行并且有真实的网络事件")
 
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	loop, err := steamkit.StartCallbackLoop(ctx, 100*time.Millisecond)
+	if err != nil {
+		log.Fatalf("启动回调循环失败: %v", err)
+	}
+
 	ticker := time.NewTicker(100 * time.Millisecond)
 	defer ticker.Stop()
 
-	timeout := time.After(5 * time.Second)
-
 	for {
 		select {
 		case <-ticker.C:
-			// 处理 Steam 回调
-			steamkit.RunCallbacks()
-
 			// 尝试接收消息
 			messages, err := sockets.ReceiveMessagesOnConnection(conn, 32)
 			if err != nil {
@@ -128,7 +135,7 @@ func main() {
 				msg.Release()
 			}
 
-		case <-timeout:
+		case <-loop.Done():
 			fmt.Println("\n超时，退出循环")
 			goto cleanup
 		}
@@ -140,7 +147,7 @@ cleanup:
 	steamnet.ClearConnectionCallback(conn)
 
 	// 关闭连接
-	err = sockets.CloseConnection(conn, 0, "正常关闭", false)
+	err = sockets.CloseConnection(conn, steamnet.EndReasonAppGeneric, "正常关闭", false)
 	if err != nil {
 		log.Printf("关闭连接失败: %v", err)
 	} else {
@@ -21,7 +21,10 @@ func main() {
 	fmt.Println("   ✓ Steam API 初始化成功")
 
 	// 获取当前用户 SteamID
-	steamID := steamkit.GetSteamID()
+	steamID, err := steamkit.GetSteamID()
+	if err != nil {
+		log.Fatalf("   ✗ 无法获取 SteamID: %v", err)
+	}
 	if steamID == 0 {
 		log.Fatal("   ✗ 无法获取 SteamID（可能未登录 Steam）")
 	}
@@ -29,9 +32,9 @@ func main() {
 
 	// 获取 ISteamNetworkingSockets 实例
 	fmt.Println("\n2. 获取 ISteamNetworkingSockets 实例...")
-	sockets := steamnet.GetSockets()
-	if sockets == nil {
-		log.Fatal("   ✗ 无法获取 ISteamNetworkingSockets 实例")
+	sockets, err := steamnet.GetSockets()
+	if err != nil {
+		log.Fatalf("   ✗ 无法获取 ISteamNetworkingSockets 实例: %v", err)
 	}
 	fmt.Println("   ✓ ISteamNetworkingSockets 实例获取成功")
 
@@ -70,11 +73,13 @@ func main() {
 			log.Printf("   ✗ 获取连接信息失败: %v", err)
 		} else {
 			fmt.Printf("   ✓ 连接状态: %s\n", info.State.String())
+			fmt.Printf("   ✓ 远程身份: %s\n", info.Identity.String())
+			fmt.Printf("   ✓ 连接描述: %s\n", info.Description)
 		}
 
 		// 关闭连接
 		fmt.Println("\n7. 关闭连接...")
-		if err := sockets.CloseConnection(connection, 0, "测试完成", false); err != nil {
+		if err := sockets.CloseConnection(connection, steamnet.EndReasonAppGeneric, "测试完成", false); err != nil {
 			log.Printf("   ✗ 关闭连接失败: %v", err)
 		} else {
 			fmt.Println("   ✓ 连接关闭成功")
@@ -0,0 +1,85 @@
+package purego
+
+import (
+	"encoding/binary"
+	"unsafe"
+)
+
+// 手动回调分发函数指针
+var (
+	ptrAPI_GetHSteamPipe                   func() int32
+	ptrAPI_ManualDispatch_Init             func()
+	ptrAPI_ManualDispatch_RunFrame         func(int32)
+	ptrAPI_ManualDispatch_GetNextCallback  func(int32, uintptr) bool
+	ptrAPI_ManualDispatch_FreeLastCallback func(int32)
+	ptrAPI_ManualDispatch_GetAPICallResult func(int32, uint64, uintptr, int32, int32, uintptr) bool
+)
+
+// registerDispatchFunctions 注册手动回调分发相关函数
+func registerDispatchFunctions() {
+	bind(&ptrAPI_GetHSteamPipe, "SteamAPI_GetHSteamPipe")
+	bind(&ptrAPI_ManualDispatch_Init, "SteamAPI_ManualDispatch_Init")
+	bind(&ptrAPI_ManualDispatch_RunFrame, "SteamAPI_ManualDispatch_RunFrame")
+	bind(&ptrAPI_ManualDispatch_GetNextCallback, "SteamAPI_ManualDispatch_GetNextCallback")
+	bind(&ptrAPI_ManualDispatch_FreeLastCallback, "SteamAPI_ManualDispatch_FreeLastCallback")
+	bind(&ptrAPI_ManualDispatch_GetAPICallResult, "SteamAPI_ManualDispatch_GetAPICallResult")
+}
+
+// callbackMsgSize 是 CallbackMsg_t 的大小
+// 结构体布局：
+//
+//	offset 0:  HSteamUser m_hSteamUser (int32)
+//	offset 4:  int m_iCallback
+//	offset 8:  uint8 *m_pubParam
+//	offset 16: int m_cubParam
+const callbackMsgSize = 24
+
+// CallGetHSteamPipe 调用 SteamAPI_GetHSteamPipe
+func CallGetHSteamPipe() int32 {
+	return ptrAPI_GetHSteamPipe()
+}
+
+// CallManualDispatchInit 启用手动回调分发
+// 必须在 SteamAPI_Init 成功之后调用，此后不能再调用 SteamAPI_RunCallbacks
+func CallManualDispatchInit() {
+	ptrAPI_ManualDispatch_Init()
+}
+
+// CallManualDispatchRunFrame 执行一帧内部处理，应在每轮取回调之前调用
+func CallManualDispatchRunFrame(pipe int32) {
+	ptrAPI_ManualDispatch_RunFrame(pipe)
+}
+
+// CallManualDispatchGetNextCallback 取出下一个待处理的回调
+// 返回的 data 指向原生内存，只在调用 CallManualDispatchFreeLastCallback 之前有效
+func CallManualDispatchGetNextCallback(pipe int32) (id int32, data []byte, ok bool) {
+	var msg [callbackMsgSize]byte
+	if !ptrAPI_ManualDispatch_GetNextCallback(pipe, uintptr(unsafe.Pointer(&msg[0]))) {
+		return 0, nil, false
+	}
+
+	id = int32(binary.LittleEndian.Uint32(msg[4:]))
+	param := uintptr(binary.LittleEndian.Uint64(msg[8:]))
+	size := int(int32(binary.LittleEndian.Uint32(msg[16:])))
+	if param != 0 && size > 0 {
+		// 通过 *unsafe.Pointer 转换，避免直接将 uintptr 转为 unsafe.Pointer
+		data = unsafe.Slice((*byte)(*(*unsafe.Pointer)(unsafe.Pointer(&param))), size)
+	}
+	return id, data, true
+}
+
+// CallManualDispatchFreeLastCallback 释放最近一次取出的回调
+func CallManualDispatchFreeLastCallback(pipe int32) {
+	ptrAPI_ManualDispatch_FreeLastCallback(pipe)
+}
+
+// CallManualDispatchGetAPICallResult 获取异步调用的结果
+// buf 接收结果结构体，expectedID 为结果的回调 ID；failed 为 true 表示 IO 失败
+func CallManualDispatchGetAPICallResult(pipe int32, call uint64, buf []byte, expectedID int32) (ok bool, failed bool) {
+	var ptr uintptr
+	if len(buf) > 0 {
+		ptr = uintptr(unsafe.Pointer(&buf[0]))
+	}
+	ok = ptrAPI_ManualDispatch_GetAPICallResult(pipe, call, ptr, int32(len(buf)), expectedID, uintptr(unsafe.Pointer(&failed)))
+	return ok, failed
+}
@@ -0,0 +1,141 @@
+package purego
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LibPathEnv 是指定 Steam 库路径的环境变量，可以是库文件或所在目录
+const LibPathEnv = "STEAMKIT_LIB_PATH"
+
+// LoadOptions 控制 Steam 库的查找方式
+type LoadOptions struct {
+	// LibPath 是显式指定的库文件或目录，设置后只尝试该路径
+	LibPath string
+
+	// SearchDirs 是额外的查找目录，相对路径以可执行文件所在目录为基准
+	SearchDirs []string
+}
+
+// LoadAttempt 记录一次加载尝试
+type LoadAttempt struct {
+	Path string // 尝试的路径
+	Err  error  // 失败原因
+}
+
+// LoadError 表示所有候选路径都加载失败
+type LoadError struct {
+	Attempts []LoadAttempt
+}
+
+// Error 实现 error 接口，列出每个尝试的路径及失败原因
+func (e *LoadError) Error() string {
+	var b strings.Builder
+	b.WriteString("could not load ")
+	b.WriteString(libName)
+	if len(e.Attempts) == 0 {
+		b.WriteString(": no candidate paths")
+		return b.String()
+	}
+	b.WriteString(", tried:")
+	for _, a := range e.Attempts {
+		b.WriteString("\n  ")
+		b.WriteString(a.Path)
+		b.WriteString(": ")
+		b.WriteString(a.Err.Error())
+	}
+	return b.String()
+}
+
+// Unwrap 返回每次尝试的错误，支持 errors.Is / errors.As
+func (e *LoadError) Unwrap() []error {
+	errs := make([]error, len(e.Attempts))
+	for i, a := range e.Attempts {
+		errs[i] = a.Err
+	}
+	return errs
+}
+
+// errNotExist 表示候选路径不存在，不再调用原生加载函数
+var errNotExist = errors.New("file does not exist")
+
+// candidatePaths 按优先级返回要尝试的库路径
+// 顺序：LibPath > STEAMKIT_LIB_PATH > 可执行文件目录及 SearchDirs > 平台默认路径
+func candidatePaths(opts LoadOptions, env string, exeDir string) []string {
+	if opts.LibPath != "" {
+		return []string{libFilePath(opts.LibPath)}
+	}
+	if env != "" {
+		return []string{libFilePath(env)}
+	}
+
+	var paths []string
+	if exeDir != "" {
+		paths = append(paths, filepath.Join(exeDir, libName))
+	}
+	for _, dir := range opts.SearchDirs {
+		if !filepath.IsAbs(dir) && exeDir != "" {
+			dir = filepath.Join(exeDir, dir)
+		}
+		paths = append(paths, filepath.Join(dir, libName))
+	}
+	paths = append(paths, defaultSearchPaths()...)
+
+	// 去重，保留第一次出现的位置
+	seen := make(map[string]bool, len(paths))
+	out := paths[:0]
+	for _, p := range paths {
+		if !seen[p] {
+			seen[p] = true
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// libFilePath 将目录转换为其中的库文件路径
+func libFilePath(path string) string {
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		return filepath.Join(path, libName)
+	}
+	return path
+}
+
+// executableDir 返回可执行文件所在目录
+func executableDir() string {
+	exe, err := os.Executable()
+	if err != nil {
+		return ""
+	}
+	if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+		exe = resolved
+	}
+	return filepath.Dir(exe)
+}
+
+// loadLib 依次尝试候选路径加载 Steam 库
+// 全部失败时返回 *LoadError，其中包含每个路径的失败原因
+func loadLib(opts LoadOptions) (uintptr, error) {
+	loadErr := &LoadError{}
+	for _, path := range candidatePaths(opts, os.Getenv(LibPathEnv), executableDir()) {
+		// 带目录的路径先检查是否存在，得到比 dlopen 更清晰的错误
+		if strings.ContainsRune(path, filepath.Separator) {
+			if _, err := os.Stat(path); err != nil {
+				if os.IsNotExist(err) {
+					err = errNotExist
+				}
+				loadErr.Attempts = append(loadErr.Attempts, LoadAttempt{Path: path, Err: err})
+				continue
+			}
+		}
+
+		lib, err := openLib(path)
+		if err == nil {
+			return lib, nil
+		}
+		loadErr.Attempts = append(loadErr.Attempts, LoadAttempt{Path: path, Err: err})
+	}
+	return 0, loadErr
+}
@@ -0,0 +1,111 @@
+package purego
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCandidatePaths(t *testing.T) {
+	dir := t.TempDir()
+	exeDir := filepath.Join(dir, "bin")
+	abs := filepath.Join(dir, "abs")
+
+	t.Run("LibPath", func(t *testing.T) {
+		paths := candidatePaths(LoadOptions{LibPath: "/opt/steam/custom.so"}, "/env/lib.so", exeDir)
+		if len(paths) != 1 || paths[0] != "/opt/steam/custom.so" {
+			t.Errorf("paths = %v, want only the explicit path", paths)
+		}
+	})
+
+	t.Run("LibPathDir", func(t *testing.T) {
+		paths := candidatePaths(LoadOptions{LibPath: dir}, "", exeDir)
+		if want := filepath.Join(dir, libName); len(paths) != 1 || paths[0] != want {
+			t.Errorf("paths = %v, want [%s]", paths, want)
+		}
+	})
+
+	t.Run("Env", func(t *testing.T) {
+		paths := candidatePaths(LoadOptions{}, "/env/lib.so", exeDir)
+		if len(paths) != 1 || paths[0] != "/env/lib.so" {
+			t.Errorf("paths = %v, want only the env path", paths)
+		}
+	})
+
+	t.Run("Search", func(t *testing.T) {
+		paths := candidatePaths(LoadOptions{SearchDirs: []string{"lib", abs, "lib"}}, "", exeDir)
+		want := []string{
+			filepath.Join(exeDir, libName),
+			filepath.Join(exeDir, "lib", libName),
+			filepath.Join(abs, libName),
+		}
+		if len(paths) < len(want) {
+			t.Fatalf("paths = %v, want prefix %v", paths, want)
+		}
+		for i, p := range want {
+			if paths[i] != p {
+				t.Errorf("paths[%d] = %s, want %s", i, paths[i], p)
+			}
+		}
+		if len(paths) != len(want)+len(defaultSearchPaths())-countDuplicates(defaultSearchPaths()) {
+			t.Errorf("paths = %v, want search dirs followed by deduplicated defaults", paths)
+		}
+	})
+}
+
+// countDuplicates 返回 paths 中重复项的数量
+func countDuplicates(paths []string) int {
+	seen := make(map[string]bool)
+	n := 0
+	for _, p := range paths {
+		if seen[p] {
+			n++
+		}
+		seen[p] = true
+	}
+	return n
+}
+
+func TestLoadLib_ReportsEveryAttempt(t *testing.T) {
+	dir := t.TempDir()
+	bogus := filepath.Join(dir, libName)
+	if err := os.WriteFile(bogus, []byte("not a library"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(LibPathEnv, "")
+
+	missing := filepath.Join(dir, "missing")
+	_, err := loadLib(LoadOptions{SearchDirs: []string{missing, dir}})
+
+	var loadErr *LoadError
+	if !errors.As(err, &loadErr) {
+		t.Fatalf("loadLib() error = %v, want *LoadError", err)
+	}
+
+	attempts := make(map[string]error)
+	for _, a := range loadErr.Attempts {
+		attempts[a.Path] = a.Err
+	}
+	if err := attempts[filepath.Join(missing, libName)]; !errors.Is(err, errNotExist) {
+		t.Errorf("missing path error = %v, want errNotExist", err)
+	}
+	if err := attempts[bogus]; err == nil || errors.Is(err, errNotExist) {
+		t.Errorf("invalid library error = %v, want load failure", err)
+	}
+
+	msg := err.Error()
+	for _, a := range loadErr.Attempts {
+		if !strings.Contains(msg, a.Path) {
+			t.Errorf("error message does not mention %s:\n%s", a.Path, msg)
+		}
+	}
+}
+
+func TestLoadError_Empty(t *testing.T) {
+	err := &LoadError{}
+	if !strings.Contains(err.Error(), "no candidate paths") {
+		t.Errorf("Error() = %q", err.Error())
+	}
+}
@@ -0,0 +1,211 @@
+package purego
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrNotInitialized 表示 Steam API 尚未初始化或已经关闭
+var ErrNotInitialized = errors.New("Steam API is not initialized")
+
+// ErrShuttingDown 表示最后一次 Release 正在进行，TryAcquire 不等待其完成
+var ErrShuttingDown = errors.New("Steam API is shutting down")
+
+// 生命周期状态
+// Init/Shutdown 采用引用计数：只有第一次 Init 真正初始化，只有最后一次 Shutdown 真正关闭。
+// generation 在每次真正初始化和关闭时递增，接口句柄记录获取时的 generation，
+// 之后 generation 变化说明句柄已失效。
+var (
+	lifecycleMu sync.Mutex
+	initRefs    int
+	initialized atomic.Bool
+	generation  atomic.Uint64
+
+	// shutdownHooks 在真正关闭之后依次执行，用于清理各子系统的会话状态
+	shutdownHooks []func()
+
+	// releasing 表示最后一次 Release 已由 BeginRelease 保留、尚未 EndRelease，
+	// 期间其他 Acquire / Release 在 releaseDone 上等待
+	releasing   bool
+	releaseDone = sync.NewCond(&lifecycleMu)
+)
+
+// 帧钩子
+// 回调循环每帧在分发 Steam 回调之后执行，各子系统（如 steamnet 的 ISteamNetworkingSockets::RunCallbacks）
+// 在获取接口时按名称注册，重复注册会覆盖；真正关闭时全部清除
+var (
+	frameHooksMu sync.Mutex
+	frameHooks   = make(map[string]func())
+	frameOrder   []string
+)
+
+// SetFrameHook 注册名为 name 的帧钩子，fn 为 nil 时移除
+func SetFrameHook(name string, fn func()) {
+	frameHooksMu.Lock()
+	defer frameHooksMu.Unlock()
+	if fn == nil {
+		if _, ok := frameHooks[name]; ok {
+			delete(frameHooks, name)
+			frameOrder = slices.DeleteFunc(frameOrder, func(n string) bool { return n == name })
+		}
+		return
+	}
+	if _, ok := frameHooks[name]; !ok {
+		frameOrder = append(frameOrder, name)
+	}
+	frameHooks[name] = fn
+}
+
+// FrameHooks 按注册顺序返回当前的帧钩子
+func FrameHooks() []func() {
+	frameHooksMu.Lock()
+	defer frameHooksMu.Unlock()
+	hooks := make([]func(), 0, len(frameOrder))
+	for _, name := range frameOrder {
+		hooks = append(hooks, frameHooks[name])
+	}
+	return hooks
+}
+
+// clearFrameHooks 清除所有帧钩子
+func clearFrameHooks() {
+	frameHooksMu.Lock()
+	defer frameHooksMu.Unlock()
+	frameHooks = make(map[string]func())
+	frameOrder = nil
+}
+
+// OnShutdown 注册在最后一次 Release 关闭之后执行的函数
+// fn 在持有生命周期锁时执行，不能调用 Acquire / Release
+func OnShutdown(fn func()) {
+	lifecycleMu.Lock()
+	defer lifecycleMu.Unlock()
+	shutdownHooks = append(shutdownHooks, fn)
+}
+
+// Acquire 增加初始化引用计数，第一次调用时执行 init
+// init 失败时引用计数不变；最后一次 Release 进行中时等待其完成后重新初始化
+func Acquire(init func() error) error {
+	lifecycleMu.Lock()
+	defer lifecycleMu.Unlock()
+
+	waitRelease()
+	return acquireLocked(init)
+}
+
+// TryAcquire 与 Acquire 相同，但最后一次 Release 进行中时不等待，直接返回 ErrShuttingDown
+// 用于在 Release 准备阶段需要等待的 goroutine（如正在停止的回调循环）中调用
+func TryAcquire(init func() error) error {
+	lifecycleMu.Lock()
+	defer lifecycleMu.Unlock()
+
+	if releasing {
+		return ErrShuttingDown
+	}
+	return acquireLocked(init)
+}
+
+// acquireLocked 执行 Acquire，调用方持有 lifecycleMu
+func acquireLocked(init func() error) error {
+	if initRefs > 0 {
+		initRefs++
+		return nil
+	}
+	if err := init(); err != nil {
+		return err
+	}
+	initRefs = 1
+	generation.Add(1)
+	initialized.Store(true)
+	return nil
+}
+
+// Release 减少初始化引用计数，计数归零时执行 shutdown 并返回 true
+// 未初始化时不做任何操作
+func Release(shutdown func()) bool {
+	if !BeginRelease() {
+		return false
+	}
+	EndRelease(shutdown)
+	return true
+}
+
+// BeginRelease 开始一次 Release，在生命周期锁内原子地判断是否为最后一次
+// 不是最后一次（或未初始化）时直接减少引用计数并返回 false；
+// 是最后一次时返回 true 并保留关闭，调用方可以在锁外完成关闭前的准备（如停止回调循环），
+// 然后必须调用 EndRelease。在此期间其他 Acquire / Release 等待关闭完成
+func BeginRelease() bool {
+	lifecycleMu.Lock()
+	defer lifecycleMu.Unlock()
+
+	waitRelease()
+	if initRefs == 0 {
+		return false
+	}
+	if initRefs > 1 {
+		initRefs--
+		return false
+	}
+	releasing = true
+	return true
+}
+
+// EndRelease 完成 BeginRelease 保留的最后一次 Release，执行 shutdown 和关闭钩子
+func EndRelease(shutdown func()) {
+	lifecycleMu.Lock()
+	defer lifecycleMu.Unlock()
+	defer func() {
+		releasing = false
+		releaseDone.Broadcast()
+	}()
+
+	initRefs = 0
+	// 先标记为未初始化，让并发的调用尽早失败，再调用原生关闭
+	initialized.Store(false)
+	generation.Add(1)
+	shutdown()
+	clearFrameHooks()
+	for _, fn := range shutdownHooks {
+		fn()
+	}
+}
+
+// waitRelease 等待进行中的最后一次 Release 完成，调用方持有 lifecycleMu
+func waitRelease() {
+	for releasing {
+		releaseDone.Wait()
+	}
+}
+
+// Initialized 检查 Steam API 是否已初始化
+func Initialized() bool {
+	return initialized.Load()
+}
+
+// Generation 返回当前的生命周期代数，用于判断接口句柄是否失效
+func Generation() uint64 {
+	return generation.Load()
+}
+
+// CheckGeneration 检查在 gen 代获取的句柄是否仍然有效
+func CheckGeneration(gen uint64) error {
+	if !initialized.Load() || generation.Load() != gen {
+		return ErrNotInitialized
+	}
+	return nil
+}
+
+// CheckInitialized 在 Steam API 未初始化时返回 ErrNotInitialized
+func CheckInitialized() error {
+	if !initialized.Load() {
+		return ErrNotInitialized
+	}
+	return nil
+}
+
+// Loaded 检查 Steam 库是否已加载并注册了函数
+func Loaded() bool {
+	return steamLib != 0
+}
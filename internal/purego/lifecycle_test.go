@@ -0,0 +1,163 @@
+package purego
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAcquireRelease(t *testing.T) {
+	inits, shutdowns := 0, 0
+	initFn := func() error { inits++; return nil }
+	shutdownFn := func() { shutdowns++ }
+
+	if err := CheckInitialized(); !errors.Is(err, ErrNotInitialized) {
+		t.Fatalf("CheckInitialized() before Acquire = %v, want ErrNotInitialized", err)
+	}
+
+	if err := Acquire(initFn); err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+	gen := Generation()
+	if err := Acquire(initFn); err != nil {
+		t.Fatalf("second Acquire() error = %v", err)
+	}
+	if inits != 1 {
+		t.Errorf("init called %d times, want 1", inits)
+	}
+	if err := CheckGeneration(gen); err != nil {
+		t.Errorf("CheckGeneration() = %v, want nil", err)
+	}
+
+	if Release(shutdownFn) {
+		t.Error("first Release() = true, want false while references remain")
+	}
+	if !Initialized() || shutdowns != 0 {
+		t.Error("Steam API shut down while references remain")
+	}
+	if !Release(shutdownFn) {
+		t.Error("last Release() = false, want true")
+	}
+	if Initialized() || shutdowns != 1 {
+		t.Errorf("after last Release: initialized = %v, shutdowns = %d", Initialized(), shutdowns)
+	}
+	if Release(shutdownFn) || shutdowns != 1 {
+		t.Error("Release() without Acquire should do nothing")
+	}
+
+	// 关闭后再次初始化，之前的句柄仍然无效
+	if err := Acquire(initFn); err != nil {
+		t.Fatalf("Acquire() after shutdown error = %v", err)
+	}
+	defer Release(shutdownFn)
+	if err := CheckGeneration(gen); !errors.Is(err, ErrNotInitialized) {
+		t.Errorf("CheckGeneration() of stale handle = %v, want ErrNotInitialized", err)
+	}
+	if err := CheckGeneration(Generation()); err != nil {
+		t.Errorf("CheckGeneration() of current handle = %v, want nil", err)
+	}
+}
+
+func TestAcquire_InitFailure(t *testing.T) {
+	initErr := errors.New("boom")
+	if err := Acquire(func() error { return initErr }); err != initErr {
+		t.Fatalf("Acquire() error = %v, want %v", err, initErr)
+	}
+	if Initialized() {
+		t.Error("Initialized() = true after failed init")
+	}
+	if Release(func() { t.Error("shutdown called after failed init") }) {
+		t.Error("Release() after failed init = true")
+	}
+}
+
+func TestOnShutdown(t *testing.T) {
+	hooks := 0
+	OnShutdown(func() { hooks++ })
+
+	if err := Acquire(func() error { return nil }); err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+	if err := Acquire(func() error { return nil }); err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+	Release(func() {})
+	if hooks != 0 {
+		t.Errorf("hook called %d times while references remain, want 0", hooks)
+	}
+	Release(func() {})
+	if hooks != 1 {
+		t.Errorf("hook called %d times after last Release, want 1", hooks)
+	}
+}
+
+func TestFrameHooks(t *testing.T) {
+	var calls []string
+	SetFrameHook("a", func() { calls = append(calls, "a1") })
+	SetFrameHook("b", func() { calls = append(calls, "b") })
+	// 重复注册覆盖之前的钩子，保持原来的顺序
+	SetFrameHook("a", func() { calls = append(calls, "a2") })
+
+	for _, fn := range FrameHooks() {
+		fn()
+	}
+	if got := strings.Join(calls, ","); got != "a2,b" {
+		t.Errorf("frame hooks called %q, want %q", got, "a2,b")
+	}
+
+	SetFrameHook("b", nil)
+	if hooks := FrameHooks(); len(hooks) != 1 {
+		t.Errorf("FrameHooks() after removing b = %d hooks, want 1", len(hooks))
+	}
+
+	// 最后一次 Release 清除所有帧钩子
+	if err := Acquire(func() error { return nil }); err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+	Release(func() {})
+	if hooks := FrameHooks(); len(hooks) != 0 {
+		t.Errorf("FrameHooks() after last Release = %d hooks, want 0", len(hooks))
+	}
+}
+
+func TestBeginRelease(t *testing.T) {
+	noop := func() error { return nil }
+	if err := Acquire(noop); err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+	if err := Acquire(noop); err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+	if BeginRelease() {
+		t.Fatal("BeginRelease() = true while references remain")
+	}
+	if !BeginRelease() {
+		t.Fatal("BeginRelease() = false for the last reference")
+	}
+
+	// 保留期间 TryAcquire 不等待，Acquire 等待关闭完成后重新初始化
+	if err := TryAcquire(noop); !errors.Is(err, ErrShuttingDown) {
+		t.Errorf("TryAcquire() error = %v, want ErrShuttingDown", err)
+	}
+	acquired := make(chan error, 1)
+	go func() { acquired <- Acquire(noop) }()
+	select {
+	case <-acquired:
+		t.Fatal("Acquire() returned while the last release was reserved")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	shutdowns := 0
+	EndRelease(func() { shutdowns++ })
+	if shutdowns != 1 {
+		t.Errorf("shutdown called %d times, want 1", shutdowns)
+	}
+	if err := <-acquired; err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+	if !Initialized() {
+		t.Error("Initialized() = false after Acquire following EndRelease")
+	}
+	Release(func() {})
+}
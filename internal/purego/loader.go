@@ -12,19 +12,26 @@ var (
 	steamLib uintptr
 )
 
-// loadLib 加载 Steam 库（平台特定实现）
-func loadLib() (uintptr, error) {
-	return loadLibPlatform()
+// Init 使用默认查找路径初始化 purego 绑定层
+func Init() error {
+	return InitWithOptions(LoadOptions{})
 }
 
-// Init 初始化 purego 绑定层
-func Init() error {
-	lib, err := loadLib()
+// InitWithOptions 按 opts 查找并加载 Steam 库，然后注册所有函数
+func InitWithOptions(opts LoadOptions) error {
+	lib, err := loadLib(opts)
 	if err != nil {
 		return fmt.Errorf("failed to load Steam library: %w", err)
 	}
 	steamLib = lib
 
+	// 为每个接口选择库中可用的最新版本
+	versions, err := negotiateInterfaces(hasSymbol)
+	if err != nil {
+		return fmt.Errorf("failed to negotiate interface versions: %w", err)
+	}
+	selectedVersions = versions
+
 	// 注册所有函数
 	if err := registerFunctions(); err != nil {
 		return fmt.Errorf("failed to register functions: %w", err)
@@ -33,6 +40,12 @@ func Init() error {
 	return nil
 }
 
+// hasSymbol 检查已加载的库中是否存在指定的符号
+func hasSymbol(name string) bool {
+	addr, err := lookupSymbol(steamLib, name)
+	return err == nil && addr != 0
+}
+
 // GetLibHandle 返回 Steam 库句柄
 func GetLibHandle() uintptr {
 	return steamLib
@@ -55,77 +68,146 @@ var (
 	// 通用函数
 	ptrAPI_RestartAppIfNecessary func(uint32) bool
 	ptrAPI_InitFlat              func(uintptr) int32
+	ptrAPI_InternalInit          func(uintptr, uintptr) int32
 	ptrAPI_Shutdown              func()
 	ptrAPI_RunCallbacks          func()
 
 	// ISteamUser
-	ptrAPI_SteamUser             func() uintptr
-	ptrAPI_ISteamUser_GetSteamID func(uintptr) uint64
+	ptrAPI_SteamUser                           func() uintptr
+	ptrAPI_ISteamUser_GetSteamID               func(uintptr) uint64
+	ptrAPI_ISteamUser_RequestEncryptedAppTicket func(uintptr, uintptr, int32) uint64
+	ptrAPI_ISteamUser_GetEncryptedAppTicket     func(uintptr, uintptr, int32, uintptr) bool
 
 	// ISteamNetworkingSockets
 	ptrAPI_SteamNetworkingSockets                      func() uintptr
 	ptrAPI_ISteamNetworkingSockets_CreateListenSocketP2P func(uintptr, int32, int32, uintptr) uint32
 	ptrAPI_ISteamNetworkingSockets_ConnectP2P            func(uintptr, uintptr, int32, int32, uintptr) uint32
+	ptrAPI_ISteamNetworkingSockets_CreateListenSocketIP  func(uintptr, uintptr, int32, uintptr) uint32
+	ptrAPI_ISteamNetworkingSockets_ConnectByIPAddress    func(uintptr, uintptr, int32, uintptr) uint32
 	ptrAPI_ISteamNetworkingSockets_AcceptConnection      func(uintptr, uint32) int32
 	ptrAPI_ISteamNetworkingSockets_CloseConnection       func(uintptr, uint32, int32, uintptr, bool) bool
 	ptrAPI_ISteamNetworkingSockets_CloseListenSocket     func(uintptr, uint32) bool
 	ptrAPI_ISteamNetworkingSockets_GetConnectionInfo     func(uintptr, uint32, uintptr) bool
+	ptrAPI_ISteamNetworkingSockets_SetConnectionUserData func(uintptr, uint32, int64) bool
+	ptrAPI_ISteamNetworkingSockets_GetConnectionUserData func(uintptr, uint32) int64
+	ptrAPI_ISteamNetworkingSockets_SetConnectionName     func(uintptr, uint32, string)
+	ptrAPI_ISteamNetworkingSockets_GetConnectionName     func(uintptr, uint32, uintptr, int32) bool
 	ptrAPI_ISteamNetworkingSockets_SendMessageToConnection func(uintptr, uint32, uintptr, uint32, int32, uintptr) int32
+	ptrAPI_ISteamNetworkingSockets_SendMessages              func(uintptr, int32, uintptr, uintptr)
 	ptrAPI_ISteamNetworkingSockets_FlushMessagesOnConnection func(uintptr, uint32) int32
 	ptrAPI_ISteamNetworkingSockets_ReceiveMessagesOnConnection func(uintptr, uint32, uintptr, int32) int32
 	ptrAPI_ISteamNetworkingSockets_ReceiveMessagesOnPollGroup func(uintptr, uint32, uintptr, int32) int32
 	ptrAPI_ISteamNetworkingSockets_GetConnectionRealTimeStatus func(uintptr, uint32, uintptr, int32, uintptr) int32
+	ptrAPI_ISteamNetworkingSockets_ConfigureConnectionLanes    func(uintptr, uint32, int32, uintptr, uintptr) int32
+	ptrAPI_ISteamNetworkingSockets_CreatePollGroup             func(uintptr) uint32
+	ptrAPI_ISteamNetworkingSockets_DestroyPollGroup            func(uintptr, uint32) bool
+	ptrAPI_ISteamNetworkingSockets_SetConnectionPollGroup      func(uintptr, uint32, uint32) bool
+	ptrAPI_ISteamNetworkingSockets_RunCallbacks                func(uintptr)
 	ptrAPI_SteamNetworkingMessage_t_Release func(uintptr)
 
+	// ISteamNetworkingUtils
+	ptrAPI_SteamNetworkingUtils                                      func() uintptr
+	ptrAPI_ISteamNetworkingUtils_AllocateMessage                     func(uintptr, int32) uintptr
+	ptrAPI_ISteamNetworkingUtils_SetGlobalConfigValueInt32           func(uintptr, int32, int32) bool
+	ptrAPI_ISteamNetworkingUtils_SetGlobalConfigValueFloat           func(uintptr, int32, float32) bool
+	ptrAPI_ISteamNetworkingUtils_SetGlobalConfigValueString          func(uintptr, int32, string) bool
+	ptrAPI_ISteamNetworkingUtils_SetGlobalConfigValuePtr             func(uintptr, int32, uintptr) bool
+	ptrAPI_ISteamNetworkingUtils_SetConnectionConfigValueInt32       func(uintptr, uint32, int32, int32) bool
+	ptrAPI_ISteamNetworkingUtils_SetConnectionConfigValueFloat       func(uintptr, uint32, int32, float32) bool
+	ptrAPI_ISteamNetworkingUtils_SetConnectionConfigValueString      func(uintptr, uint32, int32, string) bool
+	ptrAPI_ISteamNetworkingUtils_SetConfigValue                      func(uintptr, int32, int32, uintptr, int32, uintptr) bool
+	ptrAPI_ISteamNetworkingUtils_GetConfigValue                      func(uintptr, int32, int32, uintptr, uintptr, uintptr, uintptr) int32
+	ptrAPI_ISteamNetworkingUtils_GetConfigValueInfo                  func(uintptr, int32, uintptr, uintptr) string
+	ptrAPI_ISteamNetworkingUtils_IterateGenericEditableConfigValues func(uintptr, int32, bool) int32
+	ptrAPI_ISteamNetworkingUtils_GetIPv4FakeIPType                   func(uintptr, uint32) int32
+
 	// SteamNetworkingIdentity 辅助函数
 	ptrAPI_SteamNetworkingIdentity_Clear       func(uintptr)
 	ptrAPI_SteamNetworkingIdentity_SetSteamID64 func(uintptr, uint64)
 )
 
 // registerFunctions 注册所有 Steam API 函数
+// 缺失的函数只做记录，不会导致注册失败；只有初始化和关闭所必需的函数缺失时才返回错误
 func registerFunctions() error {
+	resetSymbols()
+
 	// 通用函数
-	purego.RegisterLibFunc(&ptrAPI_RestartAppIfNecessary, steamLib, "SteamAPI_RestartAppIfNecessary")
-	purego.RegisterLibFunc(&ptrAPI_InitFlat, steamLib, "SteamAPI_InitFlat")
-	purego.RegisterLibFunc(&ptrAPI_Shutdown, steamLib, "SteamAPI_Shutdown")
-	purego.RegisterLibFunc(&ptrAPI_RunCallbacks, steamLib, "SteamAPI_RunCallbacks")
+	bind(&ptrAPI_RestartAppIfNecessary, "SteamAPI_RestartAppIfNecessary")
+	bind(&ptrAPI_InitFlat, "SteamAPI_InitFlat")
+	bind(&ptrAPI_InternalInit, "SteamInternal_SteamAPI_Init")
+	bind(&ptrAPI_Shutdown, "SteamAPI_Shutdown")
+	bind(&ptrAPI_RunCallbacks, "SteamAPI_RunCallbacks")
+
+	// 接口访问函数（版本由 negotiateInterfaces 选定）
+	for i, iface := range interfaceTable {
+		bind(iface.target, selectedVersions[i].Accessor)
+	}
 
 	// ISteamUser
-	purego.RegisterLibFunc(&ptrAPI_SteamUser, steamLib, "SteamAPI_SteamUser_v023")
-	purego.RegisterLibFunc(&ptrAPI_ISteamUser_GetSteamID, steamLib, "SteamAPI_ISteamUser_GetSteamID")
+	bind(&ptrAPI_ISteamUser_GetSteamID, "SteamAPI_ISteamUser_GetSteamID")
+	bind(&ptrAPI_ISteamUser_RequestEncryptedAppTicket, "SteamAPI_ISteamUser_RequestEncryptedAppTicket")
+	bind(&ptrAPI_ISteamUser_GetEncryptedAppTicket, "SteamAPI_ISteamUser_GetEncryptedAppTicket")
+
+	// 手动回调分发
+	registerDispatchFunctions()
 
 	// ISteamNetworkingSockets
 	registerNetworkingFunctions()
 
-	return nil
+	// 初始化和关闭是必需的
+	if !Supported("SteamInternal_SteamAPI_Init") {
+		if err := Require("SteamAPI_InitFlat"); err != nil {
+			return err
+		}
+	}
+	return Require("SteamAPI_Shutdown")
 }
 
-var networkingFunctionsRegistered = false
-
 // registerNetworkingFunctions 注册 ISteamNetworkingSockets 相关函数
 func registerNetworkingFunctions() {
-	if networkingFunctionsRegistered {
-		return
-	}
-	networkingFunctionsRegistered = true
-
-	purego.RegisterLibFunc(&ptrAPI_SteamNetworkingSockets, steamLib, "SteamAPI_SteamNetworkingSockets_SteamAPI_v012")
-	purego.RegisterLibFunc(&ptrAPI_ISteamNetworkingSockets_CreateListenSocketP2P, steamLib, "SteamAPI_ISteamNetworkingSockets_CreateListenSocketP2P")
-	purego.RegisterLibFunc(&ptrAPI_ISteamNetworkingSockets_ConnectP2P, steamLib, "SteamAPI_ISteamNetworkingSockets_ConnectP2P")
-	purego.RegisterLibFunc(&ptrAPI_ISteamNetworkingSockets_AcceptConnection, steamLib, "SteamAPI_ISteamNetworkingSockets_AcceptConnection")
-	purego.RegisterLibFunc(&ptrAPI_ISteamNetworkingSockets_CloseConnection, steamLib, "SteamAPI_ISteamNetworkingSockets_CloseConnection")
-	purego.RegisterLibFunc(&ptrAPI_ISteamNetworkingSockets_CloseListenSocket, steamLib, "SteamAPI_ISteamNetworkingSockets_CloseListenSocket")
-	purego.RegisterLibFunc(&ptrAPI_ISteamNetworkingSockets_GetConnectionInfo, steamLib, "SteamAPI_ISteamNetworkingSockets_GetConnectionInfo")
-	purego.RegisterLibFunc(&ptrAPI_ISteamNetworkingSockets_SendMessageToConnection, steamLib, "SteamAPI_ISteamNetworkingSockets_SendMessageToConnection")
-	purego.RegisterLibFunc(&ptrAPI_ISteamNetworkingSockets_FlushMessagesOnConnection, steamLib, "SteamAPI_ISteamNetworkingSockets_FlushMessagesOnConnection")
-	purego.RegisterLibFunc(&ptrAPI_ISteamNetworkingSockets_ReceiveMessagesOnConnection, steamLib, "SteamAPI_ISteamNetworkingSockets_ReceiveMessagesOnConnection")
-	purego.RegisterLibFunc(&ptrAPI_ISteamNetworkingSockets_ReceiveMessagesOnPollGroup, steamLib, "SteamAPI_ISteamNetworkingSockets_ReceiveMessagesOnPollGroup")
-	purego.RegisterLibFunc(&ptrAPI_ISteamNetworkingSockets_GetConnectionRealTimeStatus, steamLib, "SteamAPI_ISteamNetworkingSockets_GetConnectionRealTimeStatus")
-	purego.RegisterLibFunc(&ptrAPI_SteamNetworkingMessage_t_Release, steamLib, "SteamAPI_SteamNetworkingMessage_t_Release")
+	bind(&ptrAPI_ISteamNetworkingSockets_CreateListenSocketP2P, "SteamAPI_ISteamNetworkingSockets_CreateListenSocketP2P")
+	bind(&ptrAPI_ISteamNetworkingSockets_ConnectP2P, "SteamAPI_ISteamNetworkingSockets_ConnectP2P")
+	bind(&ptrAPI_ISteamNetworkingSockets_CreateListenSocketIP, "SteamAPI_ISteamNetworkingSockets_CreateListenSocketIP")
+	bind(&ptrAPI_ISteamNetworkingSockets_ConnectByIPAddress, "SteamAPI_ISteamNetworkingSockets_ConnectByIPAddress")
+	bind(&ptrAPI_ISteamNetworkingSockets_AcceptConnection, "SteamAPI_ISteamNetworkingSockets_AcceptConnection")
+	bind(&ptrAPI_ISteamNetworkingSockets_CloseConnection, "SteamAPI_ISteamNetworkingSockets_CloseConnection")
+	bind(&ptrAPI_ISteamNetworkingSockets_CloseListenSocket, "SteamAPI_ISteamNetworkingSockets_CloseListenSocket")
+	bind(&ptrAPI_ISteamNetworkingSockets_GetConnectionInfo, "SteamAPI_ISteamNetworkingSockets_GetConnectionInfo")
+	bind(&ptrAPI_ISteamNetworkingSockets_SetConnectionUserData, "SteamAPI_ISteamNetworkingSockets_SetConnectionUserData")
+	bind(&ptrAPI_ISteamNetworkingSockets_GetConnectionUserData, "SteamAPI_ISteamNetworkingSockets_GetConnectionUserData")
+	bind(&ptrAPI_ISteamNetworkingSockets_SetConnectionName, "SteamAPI_ISteamNetworkingSockets_SetConnectionName")
+	bind(&ptrAPI_ISteamNetworkingSockets_GetConnectionName, "SteamAPI_ISteamNetworkingSockets_GetConnectionName")
+	bind(&ptrAPI_ISteamNetworkingSockets_SendMessageToConnection, "SteamAPI_ISteamNetworkingSockets_SendMessageToConnection")
+	bind(&ptrAPI_ISteamNetworkingSockets_SendMessages, "SteamAPI_ISteamNetworkingSockets_SendMessages")
+	bind(&ptrAPI_ISteamNetworkingSockets_FlushMessagesOnConnection, "SteamAPI_ISteamNetworkingSockets_FlushMessagesOnConnection")
+	bind(&ptrAPI_ISteamNetworkingSockets_ReceiveMessagesOnConnection, "SteamAPI_ISteamNetworkingSockets_ReceiveMessagesOnConnection")
+	bind(&ptrAPI_ISteamNetworkingSockets_ReceiveMessagesOnPollGroup, "SteamAPI_ISteamNetworkingSockets_ReceiveMessagesOnPollGroup")
+	bind(&ptrAPI_ISteamNetworkingSockets_GetConnectionRealTimeStatus, "SteamAPI_ISteamNetworkingSockets_GetConnectionRealTimeStatus")
+	bind(&ptrAPI_ISteamNetworkingSockets_ConfigureConnectionLanes, "SteamAPI_ISteamNetworkingSockets_ConfigureConnectionLanes")
+	bind(&ptrAPI_ISteamNetworkingSockets_CreatePollGroup, "SteamAPI_ISteamNetworkingSockets_CreatePollGroup")
+	bind(&ptrAPI_ISteamNetworkingSockets_DestroyPollGroup, "SteamAPI_ISteamNetworkingSockets_DestroyPollGroup")
+	bind(&ptrAPI_ISteamNetworkingSockets_SetConnectionPollGroup, "SteamAPI_ISteamNetworkingSockets_SetConnectionPollGroup")
+	bind(&ptrAPI_ISteamNetworkingSockets_RunCallbacks, "SteamAPI_ISteamNetworkingSockets_RunCallbacks")
+	bind(&ptrAPI_SteamNetworkingMessage_t_Release, "SteamAPI_SteamNetworkingMessage_t_Release")
+
+	// ISteamNetworkingUtils
+	bind(&ptrAPI_ISteamNetworkingUtils_AllocateMessage, "SteamAPI_ISteamNetworkingUtils_AllocateMessage")
+	bind(&ptrAPI_ISteamNetworkingUtils_SetGlobalConfigValueInt32, "SteamAPI_ISteamNetworkingUtils_SetGlobalConfigValueInt32")
+	bind(&ptrAPI_ISteamNetworkingUtils_SetGlobalConfigValueFloat, "SteamAPI_ISteamNetworkingUtils_SetGlobalConfigValueFloat")
+	bind(&ptrAPI_ISteamNetworkingUtils_SetGlobalConfigValueString, "SteamAPI_ISteamNetworkingUtils_SetGlobalConfigValueString")
+	bind(&ptrAPI_ISteamNetworkingUtils_SetGlobalConfigValuePtr, "SteamAPI_ISteamNetworkingUtils_SetGlobalConfigValuePtr")
+	bind(&ptrAPI_ISteamNetworkingUtils_SetConnectionConfigValueInt32, "SteamAPI_ISteamNetworkingUtils_SetConnectionConfigValueInt32")
+	bind(&ptrAPI_ISteamNetworkingUtils_SetConnectionConfigValueFloat, "SteamAPI_ISteamNetworkingUtils_SetConnectionConfigValueFloat")
+	bind(&ptrAPI_ISteamNetworkingUtils_SetConnectionConfigValueString, "SteamAPI_ISteamNetworkingUtils_SetConnectionConfigValueString")
+	bind(&ptrAPI_ISteamNetworkingUtils_SetConfigValue, "SteamAPI_ISteamNetworkingUtils_SetConfigValue")
+	bind(&ptrAPI_ISteamNetworkingUtils_GetConfigValue, "SteamAPI_ISteamNetworkingUtils_GetConfigValue")
+	bind(&ptrAPI_ISteamNetworkingUtils_GetConfigValueInfo, "SteamAPI_ISteamNetworkingUtils_GetConfigValueInfo")
+	bind(&ptrAPI_ISteamNetworkingUtils_IterateGenericEditableConfigValues, "SteamAPI_ISteamNetworkingUtils_IterateGenericEditableConfigValues")
+	bind(&ptrAPI_ISteamNetworkingUtils_GetIPv4FakeIPType, "SteamAPI_ISteamNetworkingUtils_GetIPv4FakeIPType")
 
 	// SteamNetworkingIdentity 辅助函数
-	purego.RegisterLibFunc(&ptrAPI_SteamNetworkingIdentity_Clear, steamLib, "SteamAPI_SteamNetworkingIdentity_Clear")
-	purego.RegisterLibFunc(&ptrAPI_SteamNetworkingIdentity_SetSteamID64, steamLib, "SteamAPI_SteamNetworkingIdentity_SetSteamID64")
+	bind(&ptrAPI_SteamNetworkingIdentity_Clear, "SteamAPI_SteamNetworkingIdentity_Clear")
+	bind(&ptrAPI_SteamNetworkingIdentity_SetSteamID64, "SteamAPI_SteamNetworkingIdentity_SetSteamID64")
 }
 
 // CallRestartAppIfNecessary 调用 SteamAPI_RestartAppIfNecessary
@@ -133,10 +215,18 @@ func CallRestartAppIfNecessary(appID uint32) bool {
 	return ptrAPI_RestartAppIfNecessary(appID)
 }
 
-// CallInitFlat 调用 SteamAPI_InitFlat
-func CallInitFlat() (int32, string) {
+// CallSteamAPIInit 初始化 Steam API
+// 库支持时调用 SteamInternal_SteamAPI_Init 并传入协商选定的接口版本，
+// 让 Steam 客户端检查这些版本是否可用；否则退回 SteamAPI_InitFlat
+func CallSteamAPIInit() (int32, string) {
 	var msg steamErrMsg
-	result := ptrAPI_InitFlat(uintptr(unsafe.Pointer(&msg)))
+	if ptrAPI_InternalInit == nil {
+		result := ptrAPI_InitFlat(uintptr(unsafe.Pointer(&msg)))
+		return result, msg.String()
+	}
+
+	versions := versionList(selectedVersions)
+	result := ptrAPI_InternalInit(uintptr(unsafe.Pointer(&versions[0])), uintptr(unsafe.Pointer(&msg)))
 	return result, msg.String()
 }
 
@@ -159,6 +249,31 @@ func CallGetSteamID() uint64 {
 	return ptrAPI_ISteamUser_GetSteamID(userPtr)
 }
 
+// CallRequestEncryptedAppTicket 请求加密应用票据，返回异步调用句柄
+func CallRequestEncryptedAppTicket(data []byte) uint64 {
+	userPtr := ptrAPI_SteamUser()
+	if userPtr == 0 {
+		return 0
+	}
+	var ptr uintptr
+	if len(data) > 0 {
+		ptr = uintptr(unsafe.Pointer(&data[0]))
+	}
+	return ptrAPI_ISteamUser_RequestEncryptedAppTicket(userPtr, ptr, int32(len(data)))
+}
+
+// CallGetEncryptedAppTicket 获取已请求到的加密应用票据
+// 返回写入 buf 的票据大小
+func CallGetEncryptedAppTicket(buf []byte) (uint32, bool) {
+	userPtr := ptrAPI_SteamUser()
+	if userPtr == 0 || len(buf) == 0 {
+		return 0, false
+	}
+	var size uint32
+	ok := ptrAPI_ISteamUser_GetEncryptedAppTicket(userPtr, uintptr(unsafe.Pointer(&buf[0])), int32(len(buf)), uintptr(unsafe.Pointer(&size)))
+	return size, ok
+}
+
 // CallGetSteamNetworkingSockets 获取 ISteamNetworkingSockets 接口指针
 func CallGetSteamNetworkingSockets() uintptr {
 	return ptrAPI_SteamNetworkingSockets()
@@ -174,6 +289,16 @@ func CallConnectP2P(handle uintptr, identityRemote uintptr, virtualPort int32, n
 	return ptrAPI_ISteamNetworkingSockets_ConnectP2P(handle, identityRemote, virtualPort, numOptions, options)
 }
 
+// CallCreateListenSocketIP 创建 IP 监听套接字
+func CallCreateListenSocketIP(handle uintptr, localAddress uintptr, numOptions int32, options uintptr) uint32 {
+	return ptrAPI_ISteamNetworkingSockets_CreateListenSocketIP(handle, localAddress, numOptions, options)
+}
+
+// CallConnectByIPAddress 通过 IP 地址连接到远程主机
+func CallConnectByIPAddress(handle uintptr, address uintptr, numOptions int32, options uintptr) uint32 {
+	return ptrAPI_ISteamNetworkingSockets_ConnectByIPAddress(handle, address, numOptions, options)
+}
+
 // CallAcceptConnection 接受传入连接
 func CallAcceptConnection(handle uintptr, conn uint32) int32 {
 	return ptrAPI_ISteamNetworkingSockets_AcceptConnection(handle, conn)
@@ -194,11 +319,37 @@ func CallGetConnectionInfo(handle uintptr, conn uint32, info uintptr) bool {
 	return ptrAPI_ISteamNetworkingSockets_GetConnectionInfo(handle, conn, info)
 }
 
+// CallSetConnectionUserData 设置连接的用户数据
+func CallSetConnectionUserData(handle uintptr, peer uint32, userData int64) bool {
+	return ptrAPI_ISteamNetworkingSockets_SetConnectionUserData(handle, peer, userData)
+}
+
+// CallGetConnectionUserData 获取连接的用户数据，句柄无效时返回 -1
+func CallGetConnectionUserData(handle uintptr, peer uint32) int64 {
+	return ptrAPI_ISteamNetworkingSockets_GetConnectionUserData(handle, peer)
+}
+
+// CallSetConnectionName 设置连接名称（用于调试输出）
+func CallSetConnectionName(handle uintptr, peer uint32, name string) {
+	ptrAPI_ISteamNetworkingSockets_SetConnectionName(handle, peer, name)
+}
+
+// CallGetConnectionName 获取连接名称
+func CallGetConnectionName(handle uintptr, peer uint32, name uintptr, maxLen int32) bool {
+	return ptrAPI_ISteamNetworkingSockets_GetConnectionName(handle, peer, name, maxLen)
+}
+
 // CallSendMessageToConnection 发送消息到连接
 func CallSendMessageToConnection(handle uintptr, conn uint32, data uintptr, dataSize uint32, flags int32, outMessageNumber uintptr) int32 {
 	return ptrAPI_ISteamNetworkingSockets_SendMessageToConnection(handle, conn, data, dataSize, flags, outMessageNumber)
 }
 
+// CallSendMessages 批量发送消息
+// outMessageNumberOrResult 为 int64 数组，正数为消息序号，负数为 -EResult
+func CallSendMessages(handle uintptr, numMessages int32, messages uintptr, outMessageNumberOrResult uintptr) {
+	ptrAPI_ISteamNetworkingSockets_SendMessages(handle, numMessages, messages, outMessageNumberOrResult)
+}
+
 // CallFlushMessagesOnConnection 刷新连接上的消息
 func CallFlushMessagesOnConnection(handle uintptr, conn uint32) int32 {
 	return ptrAPI_ISteamNetworkingSockets_FlushMessagesOnConnection(handle, conn)
@@ -219,11 +370,113 @@ func CallReleaseMessage(messagePtr uintptr) {
 	ptrAPI_SteamNetworkingMessage_t_Release(messagePtr)
 }
 
+// CallRunCallbacksSockets 调用 ISteamNetworkingSockets::RunCallbacks
+// 通过配置项注册的网络回调（如连接状态变化）在此调用期间触发
+func CallRunCallbacksSockets(handle uintptr) {
+	ptrAPI_ISteamNetworkingSockets_RunCallbacks(handle)
+}
+
+// NewCallback 将 Go 函数转换为 C 函数指针
+// 可创建的回调数量有限且不会释放，调用方应只为每个用途创建一次
+func NewCallback(fn any) uintptr {
+	return purego.NewCallback(fn)
+}
+
 // CallGetConnectionRealTimeStatus 获取连接的实时状态
 func CallGetConnectionRealTimeStatus(handle uintptr, conn uint32, status uintptr, numLanes int32, lanes uintptr) int32 {
 	return ptrAPI_ISteamNetworkingSockets_GetConnectionRealTimeStatus(handle, conn, status, numLanes, lanes)
 }
 
+// CallConfigureConnectionLanes 配置连接的通道
+func CallConfigureConnectionLanes(handle uintptr, conn uint32, numLanes int32, lanePriorities uintptr, laneWeights uintptr) int32 {
+	return ptrAPI_ISteamNetworkingSockets_ConfigureConnectionLanes(handle, conn, numLanes, lanePriorities, laneWeights)
+}
+
+// CallCreatePollGroup 创建轮询组
+func CallCreatePollGroup(handle uintptr) uint32 {
+	return ptrAPI_ISteamNetworkingSockets_CreatePollGroup(handle)
+}
+
+// CallDestroyPollGroup 销毁轮询组
+func CallDestroyPollGroup(handle uintptr, group uint32) bool {
+	return ptrAPI_ISteamNetworkingSockets_DestroyPollGroup(handle, group)
+}
+
+// CallSetConnectionPollGroup 将连接加入轮询组
+func CallSetConnectionPollGroup(handle uintptr, conn uint32, group uint32) bool {
+	return ptrAPI_ISteamNetworkingSockets_SetConnectionPollGroup(handle, conn, group)
+}
+
+// CallGetSteamNetworkingUtils 获取 ISteamNetworkingUtils 接口指针
+func CallGetSteamNetworkingUtils() uintptr {
+	return ptrAPI_SteamNetworkingUtils()
+}
+
+// CallAllocateMessage 分配一条可用于 SendMessages 的消息
+func CallAllocateMessage(handle uintptr, cbAllocateBuffer int32) uintptr {
+	return ptrAPI_ISteamNetworkingUtils_AllocateMessage(handle, cbAllocateBuffer)
+}
+
+// CallSetGlobalConfigValueInt32 设置 int32 类型的全局配置
+func CallSetGlobalConfigValueInt32(handle uintptr, value int32, val int32) bool {
+	return ptrAPI_ISteamNetworkingUtils_SetGlobalConfigValueInt32(handle, value, val)
+}
+
+// CallSetGlobalConfigValueFloat 设置 float 类型的全局配置
+func CallSetGlobalConfigValueFloat(handle uintptr, value int32, val float32) bool {
+	return ptrAPI_ISteamNetworkingUtils_SetGlobalConfigValueFloat(handle, value, val)
+}
+
+// CallSetGlobalConfigValueString 设置字符串类型的全局配置
+func CallSetGlobalConfigValueString(handle uintptr, value int32, val string) bool {
+	return ptrAPI_ISteamNetworkingUtils_SetGlobalConfigValueString(handle, value, val)
+}
+
+// CallSetGlobalConfigValuePtr 设置指针类型的全局配置
+func CallSetGlobalConfigValuePtr(handle uintptr, value int32, val uintptr) bool {
+	return ptrAPI_ISteamNetworkingUtils_SetGlobalConfigValuePtr(handle, value, val)
+}
+
+// CallSetConnectionConfigValueInt32 设置 int32 类型的连接配置
+func CallSetConnectionConfigValueInt32(handle uintptr, conn uint32, value int32, val int32) bool {
+	return ptrAPI_ISteamNetworkingUtils_SetConnectionConfigValueInt32(handle, conn, value, val)
+}
+
+// CallSetConnectionConfigValueFloat 设置 float 类型的连接配置
+func CallSetConnectionConfigValueFloat(handle uintptr, conn uint32, value int32, val float32) bool {
+	return ptrAPI_ISteamNetworkingUtils_SetConnectionConfigValueFloat(handle, conn, value, val)
+}
+
+// CallSetConnectionConfigValueString 设置字符串类型的连接配置
+func CallSetConnectionConfigValueString(handle uintptr, conn uint32, value int32, val string) bool {
+	return ptrAPI_ISteamNetworkingUtils_SetConnectionConfigValueString(handle, conn, value, val)
+}
+
+// CallSetConfigValue 在任意作用域设置任意类型的配置
+func CallSetConfigValue(handle uintptr, value int32, scopeType int32, scopeObj uintptr, dataType int32, arg uintptr) bool {
+	return ptrAPI_ISteamNetworkingUtils_SetConfigValue(handle, value, scopeType, scopeObj, dataType, arg)
+}
+
+// CallGetConfigValue 获取配置值
+func CallGetConfigValue(handle uintptr, value int32, scopeType int32, scopeObj uintptr, outDataType uintptr, result uintptr, cbResult uintptr) int32 {
+	return ptrAPI_ISteamNetworkingUtils_GetConfigValue(handle, value, scopeType, scopeObj, outDataType, result, cbResult)
+}
+
+// CallGetConfigValueInfo 获取配置项的名称、数据类型和作用域
+func CallGetConfigValueInfo(handle uintptr, value int32, outDataType uintptr, outScope uintptr) string {
+	return ptrAPI_ISteamNetworkingUtils_GetConfigValueInfo(handle, value, outDataType, outScope)
+}
+
+// CallIterateGenericEditableConfigValues 遍历可编辑的配置项
+func CallIterateGenericEditableConfigValues(handle uintptr, current int32, enumerateDevVars bool) int32 {
+	return ptrAPI_ISteamNetworkingUtils_IterateGenericEditableConfigValues(handle, current, enumerateDevVars)
+}
+
+// CallGetIPv4FakeIPType 获取 IPv4 地址的 FakeIP 类型
+func CallGetIPv4FakeIPType(handle uintptr, ip uint32) int32 {
+	return ptrAPI_ISteamNetworkingUtils_GetIPv4FakeIPType(handle, ip)
+}
+
 // CallSteamNetworkingIdentityClear 清除/初始化 SteamNetworkingIdentity 结构体
 func CallSteamNetworkingIdentityClear(identity uintptr) {
 	ptrAPI_SteamNetworkingIdentity_Clear(identity)
@@ -3,31 +3,31 @@
 package purego
 
 import (
-	"fmt"
 	"os"
 	"path/filepath"
 
 	"github.com/ebitengine/purego"
 )
 
-// loadLibPlatform 加载 macOS 平台的 Steam 库
-func loadLibPlatform() (uintptr, error) {
-	// 尝试多个可能的路径
-	paths := []string{
-		"libsteam_api.dylib",                                 // 当前目录
-		filepath.Join(".", "libsteam_api.dylib"),             // 当前目录（显式）
-		filepath.Join(os.Getenv("HOME"), "Library", "Application Support", "Steam", "Steam.AppBundle", "Steam", "Contents", "MacOS", "libsteam_api.dylib"), // Steam 安装目录
-		"/usr/local/lib/libsteam_api.dylib",                  // 本地库目录
-	}
+// libName 是 macOS 平台的 Steam 库文件名
+const libName = "libsteam_api.dylib"
 
-	var lastErr error
-	for _, path := range paths {
-		lib, err := purego.Dlopen(path, purego.RTLD_NOW|purego.RTLD_GLOBAL)
-		if err == nil {
-			return lib, nil
-		}
-		lastErr = err
+// defaultSearchPaths 返回 macOS 平台的默认查找路径
+func defaultSearchPaths() []string {
+	return []string{
+		libName,                     // 动态链接器搜索路径
+		filepath.Join(".", libName), // 当前目录
+		filepath.Join(os.Getenv("HOME"), "Library", "Application Support", "Steam", "Steam.AppBundle", "Steam", "Contents", "MacOS", libName), // Steam 安装目录
+		"/usr/local/lib/" + libName, // 本地库目录
 	}
+}
+
+// openLib 加载指定路径的 Steam 库
+func openLib(path string) (uintptr, error) {
+	return purego.Dlopen(path, purego.RTLD_NOW|purego.RTLD_GLOBAL)
+}
 
-	return 0, fmt.Errorf("failed to load libsteam_api.dylib: %w", lastErr)
+// lookupSymbol 查找库中的符号地址，找不到时返回错误
+func lookupSymbol(lib uintptr, name string) (uintptr, error) {
+	return purego.Dlsym(lib, name)
 }
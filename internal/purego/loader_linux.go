@@ -3,32 +3,32 @@
 package purego
 
 import (
-	"fmt"
 	"os"
 	"path/filepath"
 
 	"github.com/ebitengine/purego"
 )
 
-// loadLibPlatform 加载 Linux 平台的 Steam 库
-func loadLibPlatform() (uintptr, error) {
-	// 尝试多个可能的路径
-	paths := []string{
-		"libsteam_api.so",                                    // 当前目录
-		filepath.Join(".", "libsteam_api.so"),                // 当前目录（显式）
-		filepath.Join(os.Getenv("HOME"), ".steam", "sdk64", "libsteam_api.so"), // Steam SDK 目录
-		"/usr/lib/libsteam_api.so",                           // 系统库目录
-		"/usr/local/lib/libsteam_api.so",                     // 本地库目录
-	}
+// libName 是 Linux 平台的 Steam 库文件名
+const libName = "libsteam_api.so"
 
-	var lastErr error
-	for _, path := range paths {
-		lib, err := purego.Dlopen(path, purego.RTLD_NOW|purego.RTLD_GLOBAL)
-		if err == nil {
-			return lib, nil
-		}
-		lastErr = err
+// defaultSearchPaths 返回 Linux 平台的默认查找路径
+func defaultSearchPaths() []string {
+	return []string{
+		libName,                     // 动态链接器搜索路径（LD_LIBRARY_PATH 等）
+		filepath.Join(".", libName), // 当前目录
+		filepath.Join(os.Getenv("HOME"), ".steam", "sdk64", libName), // Steam SDK 目录
+		"/usr/lib/" + libName,       // 系统库目录
+		"/usr/local/lib/" + libName, // 本地库目录
 	}
+}
+
+// openLib 加载指定路径的 Steam 库
+func openLib(path string) (uintptr, error) {
+	return purego.Dlopen(path, purego.RTLD_NOW|purego.RTLD_GLOBAL)
+}
 
-	return 0, fmt.Errorf("failed to load libsteam_api.so: %w", lastErr)
+// lookupSymbol 查找库中的符号地址，找不到时返回错误
+func lookupSymbol(lib uintptr, name string) (uintptr, error) {
+	return purego.Dlsym(lib, name)
 }
@@ -3,29 +3,30 @@
 package purego
 
 import (
-	"fmt"
 	"os"
 	"path/filepath"
 	"syscall"
 )
 
-// loadLibPlatform 加载 Windows 平台的 Steam 库
-func loadLibPlatform() (uintptr, error) {
-	// 尝试多个可能的路径
-	paths := []string{
-		"steam_api64.dll",                                    // 当前目录
-		filepath.Join(".", "steam_api64.dll"),                // 当前目录（显式）
-		filepath.Join(os.Getenv("ProgramFiles(x86)"), "Steam", "steam_api64.dll"), // Steam 安装目录
-	}
+// libName 是 Windows 平台的 Steam 库文件名
+const libName = "steam_api64.dll"
 
-	var lastErr error
-	for _, path := range paths {
-		handle, err := syscall.LoadLibrary(path)
-		if err == nil {
-			return uintptr(handle), nil
-		}
-		lastErr = err
+// defaultSearchPaths 返回 Windows 平台的默认查找路径
+func defaultSearchPaths() []string {
+	return []string{
+		libName,                     // DLL 搜索路径
+		filepath.Join(".", libName), // 当前目录
+		filepath.Join(os.Getenv("ProgramFiles(x86)"), "Steam", libName), // Steam 安装目录
 	}
+}
+
+// openLib 加载指定路径的 Steam 库
+func openLib(path string) (uintptr, error) {
+	handle, err := syscall.LoadLibrary(path)
+	return uintptr(handle), err
+}
 
-	return 0, fmt.Errorf("failed to load steam_api64.dll: %w", lastErr)
+// lookupSymbol 查找库中的符号地址，找不到时返回错误
+func lookupSymbol(lib uintptr, name string) (uintptr, error) {
+	return syscall.GetProcAddress(syscall.Handle(lib), name)
 }
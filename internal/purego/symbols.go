@@ -0,0 +1,116 @@
+package purego
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/ebitengine/purego"
+)
+
+// ErrUnsupported 表示加载的 Steam 库中没有所需的函数
+var ErrUnsupported = errors.New("not supported by the loaded Steam library")
+
+// UnsupportedError 表示调用了加载的 Steam 库中不存在的函数
+type UnsupportedError struct {
+	Symbol string // 缺失的符号名
+}
+
+// Error 实现 error 接口
+func (e *UnsupportedError) Error() string {
+	return e.Symbol + ": " + ErrUnsupported.Error()
+}
+
+// Is 使 errors.Is(err, ErrUnsupported) 成立
+func (e *UnsupportedError) Is(target error) bool {
+	return target == ErrUnsupported
+}
+
+// Symbol 描述一个 flat API 函数的解析结果
+type Symbol struct {
+	Name      string // 符号名，如 "SteamAPI_ISteamNetworkingSockets_CreatePollGroup"
+	Interface string // 所属接口，如 "ISteamNetworkingSockets"
+	Method    string // 方法名，如 "CreatePollGroup"
+	Available bool   // 加载的库中是否存在
+}
+
+var (
+	symbolsMu sync.RWMutex
+	symbols   []Symbol
+	available map[string]bool
+)
+
+// resetSymbols 清空符号记录（每次注册前调用）
+func resetSymbols() {
+	symbolsMu.Lock()
+	defer symbolsMu.Unlock()
+	symbols = nil
+	available = make(map[string]bool)
+}
+
+// bind 在库中存在 name 时将其注册到 fptr，否则记录为缺失
+// 缺失的函数保持为 nil，调用方在调用前通过 Supported / Require 检查
+func bind(fptr any, name string) bool {
+	ok := hasSymbol(name)
+	if ok {
+		purego.RegisterLibFunc(fptr, steamLib, name)
+	} else {
+		// 清除之前加载的库留下的函数
+		fn := reflect.ValueOf(fptr).Elem()
+		fn.Set(reflect.Zero(fn.Type()))
+	}
+
+	iface, method := splitSymbol(name)
+	symbolsMu.Lock()
+	defer symbolsMu.Unlock()
+	symbols = append(symbols, Symbol{Name: name, Interface: iface, Method: method, Available: ok})
+	available[name] = ok
+	return ok
+}
+
+// splitSymbol 将 flat API 符号名拆分为接口名和方法名
+func splitSymbol(name string) (iface, method string) {
+	rest, ok := strings.CutPrefix(name, "SteamAPI_")
+	if !ok {
+		return "SteamAPI", name
+	}
+	if strings.HasPrefix(rest, "ISteam") {
+		if iface, method, ok := strings.Cut(rest, "_"); ok {
+			return iface, method
+		}
+	}
+	for _, helper := range []string{"SteamNetworkingMessage_t", "SteamNetworkingIdentity"} {
+		if method, ok := strings.CutPrefix(rest, helper+"_"); ok {
+			return helper, method
+		}
+	}
+	return "SteamAPI", rest
+}
+
+// Supported 检查加载的库中是否存在所有指定的函数
+func Supported(names ...string) bool {
+	return Require(names...) == nil
+}
+
+// Require 检查加载的库中是否存在所有指定的函数
+// 第一个缺失的函数以 *UnsupportedError 返回
+func Require(names ...string) error {
+	symbolsMu.RLock()
+	defer symbolsMu.RUnlock()
+	for _, name := range names {
+		if !available[name] {
+			return &UnsupportedError{Symbol: name}
+		}
+	}
+	return nil
+}
+
+// Symbols 返回所有函数的解析结果（按注册顺序）
+func Symbols() []Symbol {
+	symbolsMu.RLock()
+	defer symbolsMu.RUnlock()
+	out := make([]Symbol, len(symbols))
+	copy(out, symbols)
+	return out
+}
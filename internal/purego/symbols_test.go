@@ -0,0 +1,71 @@
+package purego
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSplitSymbol(t *testing.T) {
+	tests := []struct {
+		name   string
+		iface  string
+		method string
+	}{
+		{"SteamAPI_ISteamNetworkingSockets_CreatePollGroup", "ISteamNetworkingSockets", "CreatePollGroup"},
+		{"SteamAPI_ISteamUser_GetSteamID", "ISteamUser", "GetSteamID"},
+		{"SteamAPI_SteamNetworkingMessage_t_Release", "SteamNetworkingMessage_t", "Release"},
+		{"SteamAPI_SteamNetworkingIdentity_SetSteamID64", "SteamNetworkingIdentity", "SetSteamID64"},
+		{"SteamAPI_ManualDispatch_Init", "SteamAPI", "ManualDispatch_Init"},
+		{"SteamAPI_SteamUser_v023", "SteamAPI", "SteamUser_v023"},
+		{"SteamInternal_SteamAPI_Init", "SteamAPI", "SteamInternal_SteamAPI_Init"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			iface, method := splitSymbol(tt.name)
+			if iface != tt.iface || method != tt.method {
+				t.Errorf("splitSymbol() = (%s, %s), want (%s, %s)", iface, method, tt.iface, tt.method)
+			}
+		})
+	}
+}
+
+func TestBind_MissingSymbol(t *testing.T) {
+	resetSymbols()
+	defer resetSymbols()
+
+	fn := func() {}
+	if bind(&fn, "SteamAPI_ISteamFake_DoesNotExist") {
+		t.Fatal("bind() = true for missing symbol")
+	}
+	if fn != nil {
+		t.Error("bind() should clear the function pointer of a missing symbol")
+	}
+
+	err := Require("SteamAPI_ISteamFake_DoesNotExist")
+	var unsupported *UnsupportedError
+	if !errors.As(err, &unsupported) || unsupported.Symbol != "SteamAPI_ISteamFake_DoesNotExist" {
+		t.Errorf("Require() error = %v, want *UnsupportedError for the missing symbol", err)
+	}
+	if !errors.Is(err, ErrUnsupported) {
+		t.Errorf("errors.Is(%v, ErrUnsupported) = false", err)
+	}
+	if Supported("SteamAPI_ISteamFake_DoesNotExist") {
+		t.Error("Supported() = true for missing symbol")
+	}
+
+	syms := Symbols()
+	if len(syms) != 1 || syms[0].Interface != "ISteamFake" || syms[0].Method != "DoesNotExist" || syms[0].Available {
+		t.Errorf("Symbols() = %+v", syms)
+	}
+}
+
+func TestRequire_NotRegistered(t *testing.T) {
+	resetSymbols()
+	if err := Require("SteamAPI_Shutdown"); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("Require() before registration error = %v, want ErrUnsupported", err)
+	}
+	if err := Require(); err != nil {
+		t.Errorf("Require() with no symbols error = %v, want nil", err)
+	}
+}
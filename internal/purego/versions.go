@@ -0,0 +1,110 @@
+package purego
+
+import (
+	"fmt"
+	"strings"
+)
+
+// InterfaceVersion 描述一个接口协商后选定的版本
+type InterfaceVersion struct {
+	Interface string // 接口名，如 "SteamUser"
+	Version   string // 接口版本字符串，如 "SteamUser023"
+	Accessor  string // 获取接口指针的 flat 函数，如 "SteamAPI_SteamUser_v023"
+}
+
+// interfaceCandidate 是接口的一个受支持版本
+type interfaceCandidate struct {
+	version  string
+	accessor string
+}
+
+// interfaceAccessor 描述一个接口访问函数及其受支持的版本（从新到旧）
+// 只列出本绑定使用的 flat 方法在签名上兼容的版本
+type interfaceAccessor struct {
+	name       string
+	target     *func() uintptr
+	candidates []interfaceCandidate
+}
+
+// interfaceTable 是所有接口访问函数的版本表
+var interfaceTable = []interfaceAccessor{
+	{
+		name:   "SteamUser",
+		target: &ptrAPI_SteamUser,
+		candidates: []interfaceCandidate{
+			{"SteamUser023", "SteamAPI_SteamUser_v023"},
+			{"SteamUser022", "SteamAPI_SteamUser_v022"},
+			{"SteamUser021", "SteamAPI_SteamUser_v021"},
+		},
+	},
+	{
+		name:   "SteamNetworkingSockets",
+		target: &ptrAPI_SteamNetworkingSockets,
+		candidates: []interfaceCandidate{
+			{"SteamNetworkingSockets012", "SteamAPI_SteamNetworkingSockets_SteamAPI_v012"},
+			// v012 只新增了 FakeIP 相关方法（本绑定未使用），
+			// v011 已包含 lanes 和 SendMessages，其余方法签名与 v012 相同
+			{"SteamNetworkingSockets011", "SteamAPI_SteamNetworkingSockets_SteamAPI_v011"},
+		},
+	},
+	{
+		name:   "SteamNetworkingUtils",
+		target: &ptrAPI_SteamNetworkingUtils,
+		candidates: []interfaceCandidate{
+			// v003 的 GetConfigValueInfo 参数不同且没有 IterateGenericEditableConfigValues，不能回退
+			{"SteamNetworkingUtils004", "SteamAPI_SteamNetworkingUtils_SteamAPI_v004"},
+		},
+	},
+}
+
+// selectedVersions 是最近一次协商的结果，与 interfaceTable 一一对应
+var selectedVersions []InterfaceVersion
+
+// negotiateInterfaces 为每个接口选择库中存在的最新版本
+// hasSymbol 报告库中是否存在指定的符号
+func negotiateInterfaces(hasSymbol func(name string) bool) ([]InterfaceVersion, error) {
+	selected := make([]InterfaceVersion, 0, len(interfaceTable))
+	for _, iface := range interfaceTable {
+		found := false
+		for _, c := range iface.candidates {
+			if hasSymbol(c.accessor) {
+				selected = append(selected, InterfaceVersion{
+					Interface: iface.name,
+					Version:   c.version,
+					Accessor:  c.accessor,
+				})
+				found = true
+				break
+			}
+		}
+		if !found {
+			accessors := make([]string, len(iface.candidates))
+			for i, c := range iface.candidates {
+				accessors[i] = c.accessor
+			}
+			return nil, fmt.Errorf("no supported version of %s found (tried %s)", iface.name, strings.Join(accessors, ", "))
+		}
+	}
+	return selected, nil
+}
+
+// versionList 返回传给 SteamInternal_SteamAPI_Init 的接口版本列表
+// 格式为以 0 结尾的版本字符串依次拼接，最后再以一个 0 结束
+func versionList(versions []InterfaceVersion) []byte {
+	var b []byte
+	for _, v := range versions {
+		b = append(b, v.Version...)
+		b = append(b, 0)
+	}
+	return append(b, 0)
+}
+
+// SelectedInterfaceVersions 返回协商选定的接口版本，未初始化时返回 nil
+func SelectedInterfaceVersions() []InterfaceVersion {
+	out := make([]InterfaceVersion, len(selectedVersions))
+	copy(out, selectedVersions)
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
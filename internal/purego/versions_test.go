@@ -0,0 +1,91 @@
+package purego
+
+import (
+	"strings"
+	"testing"
+)
+
+// symbolSet 返回只包含指定符号的 hasSymbol 函数
+func symbolSet(names ...string) func(string) bool {
+	set := make(map[string]bool, len(names))
+	for _, n := range names {
+		set[n] = true
+	}
+	return func(name string) bool { return set[name] }
+}
+
+func TestNegotiateInterfaces_Newest(t *testing.T) {
+	versions, err := negotiateInterfaces(symbolSet(
+		"SteamAPI_SteamUser_v022",
+		"SteamAPI_SteamUser_v023",
+		"SteamAPI_SteamNetworkingSockets_SteamAPI_v012",
+		"SteamAPI_SteamNetworkingSockets_SteamAPI_v011",
+		"SteamAPI_SteamNetworkingUtils_SteamAPI_v004",
+	))
+	if err != nil {
+		t.Fatalf("negotiateInterfaces() error = %v", err)
+	}
+
+	want := []string{"SteamUser023", "SteamNetworkingSockets012", "SteamNetworkingUtils004"}
+	if len(versions) != len(want) {
+		t.Fatalf("versions = %v, want %v", versions, want)
+	}
+	for i, v := range versions {
+		if v.Version != want[i] {
+			t.Errorf("versions[%d] = %s, want %s", i, v.Version, want[i])
+		}
+		if v.Interface != interfaceTable[i].name {
+			t.Errorf("versions[%d].Interface = %s, want %s", i, v.Interface, interfaceTable[i].name)
+		}
+	}
+}
+
+func TestNegotiateInterfaces_Fallback(t *testing.T) {
+	versions, err := negotiateInterfaces(symbolSet(
+		"SteamAPI_SteamUser_v021",
+		"SteamAPI_SteamNetworkingSockets_SteamAPI_v011",
+		"SteamAPI_SteamNetworkingUtils_SteamAPI_v004",
+	))
+	if err != nil {
+		t.Fatalf("negotiateInterfaces() error = %v", err)
+	}
+	if versions[0].Accessor != "SteamAPI_SteamUser_v021" || versions[1].Version != "SteamNetworkingSockets011" || versions[2].Version != "SteamNetworkingUtils004" {
+		t.Errorf("versions = %v, want oldest supported versions", versions)
+	}
+}
+
+// SteamNetworkingUtils003 的方法签名不兼容，不能作为回退版本
+func TestNegotiateInterfaces_IncompatibleUtils(t *testing.T) {
+	_, err := negotiateInterfaces(symbolSet(
+		"SteamAPI_SteamUser_v023",
+		"SteamAPI_SteamNetworkingSockets_SteamAPI_v012",
+		"SteamAPI_SteamNetworkingUtils_SteamAPI_v003",
+	))
+	if err == nil || !strings.Contains(err.Error(), "SteamNetworkingUtils") {
+		t.Errorf("negotiateInterfaces() error = %v, want missing SteamNetworkingUtils", err)
+	}
+}
+
+func TestNegotiateInterfaces_Missing(t *testing.T) {
+	_, err := negotiateInterfaces(symbolSet(
+		"SteamAPI_SteamUser_v023",
+		"SteamAPI_SteamNetworkingSockets_SteamAPI_v009",
+	))
+	if err == nil {
+		t.Fatal("negotiateInterfaces() error = nil, want error for missing interface")
+	}
+	if !strings.Contains(err.Error(), "SteamNetworkingSockets") || !strings.Contains(err.Error(), "SteamAPI_SteamNetworkingSockets_SteamAPI_v012") {
+		t.Errorf("error = %q, want interface name and tried accessors", err)
+	}
+}
+
+func TestVersionList(t *testing.T) {
+	got := versionList([]InterfaceVersion{
+		{Version: "SteamUser023"},
+		{Version: "SteamNetworkingUtils004"},
+	})
+	want := "SteamUser023\x00SteamNetworkingUtils004\x00\x00"
+	if string(got) != want {
+		t.Errorf("versionList() = %q, want %q", got, want)
+	}
+}
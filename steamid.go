@@ -0,0 +1,395 @@
+package steamkit
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SteamID 是 64 位的 Steam 账号标识
+// 位布局（从低到高）：
+//
+//	bits  0-31: 账号 ID
+//	bits 32-51: 实例
+//	bits 52-55: 账号类型
+//	bits 56-63: 宇宙
+type SteamID uint64
+
+// Universe 表示 SteamID 所属的宇宙（EUniverse）
+type Universe uint8
+
+const (
+	UniverseInvalid  Universe = 0
+	UniversePublic   Universe = 1
+	UniverseBeta     Universe = 2
+	UniverseInternal Universe = 3
+	UniverseDev      Universe = 4
+)
+
+// String 返回宇宙的名称
+func (u Universe) String() string {
+	switch u {
+	case UniverseInvalid:
+		return "Invalid"
+	case UniversePublic:
+		return "Public"
+	case UniverseBeta:
+		return "Beta"
+	case UniverseInternal:
+		return "Internal"
+	case UniverseDev:
+		return "Dev"
+	default:
+		return fmt.Sprintf("Universe(%d)", uint8(u))
+	}
+}
+
+// AccountType 表示 SteamID 的账号类型（EAccountType）
+type AccountType uint8
+
+const (
+	AccountTypeInvalid        AccountType = 0
+	AccountTypeIndividual     AccountType = 1  // 普通用户
+	AccountTypeMultiseat      AccountType = 2  // 多席位（如网吧）账号
+	AccountTypeGameServer     AccountType = 3  // 持久（非匿名）游戏服务器
+	AccountTypeAnonGameServer AccountType = 4  // 匿名游戏服务器
+	AccountTypePending        AccountType = 5  // 等待验证的账号
+	AccountTypeContentServer  AccountType = 6  // 内容服务器
+	AccountTypeClan           AccountType = 7  // 组
+	AccountTypeChat           AccountType = 8  // 聊天室
+	AccountTypeConsoleUser    AccountType = 9  // 主机平台用户
+	AccountTypeAnonUser       AccountType = 10 // 匿名用户
+)
+
+// String 返回账号类型的名称
+func (t AccountType) String() string {
+	switch t {
+	case AccountTypeInvalid:
+		return "Invalid"
+	case AccountTypeIndividual:
+		return "Individual"
+	case AccountTypeMultiseat:
+		return "Multiseat"
+	case AccountTypeGameServer:
+		return "GameServer"
+	case AccountTypeAnonGameServer:
+		return "AnonGameServer"
+	case AccountTypePending:
+		return "Pending"
+	case AccountTypeContentServer:
+		return "ContentServer"
+	case AccountTypeClan:
+		return "Clan"
+	case AccountTypeChat:
+		return "Chat"
+	case AccountTypeConsoleUser:
+		return "ConsoleUser"
+	case AccountTypeAnonUser:
+		return "AnonUser"
+	default:
+		return fmt.Sprintf("AccountType(%d)", uint8(t))
+	}
+}
+
+// 常用的实例取值
+const (
+	InstanceAll     uint32 = 0
+	InstanceDesktop uint32 = 1
+	InstanceConsole uint32 = 2
+	InstanceWeb     uint32 = 4
+
+	// 聊天室 SteamID 的实例标志位
+	InstanceFlagClan  uint32 = 1 << 19 // 组聊天
+	InstanceFlagLobby uint32 = 1 << 18 // 大厅
+	InstanceFlagMMS   uint32 = 1 << 17 // 匹配大厅
+)
+
+// 位域掩码
+const (
+	steamIDAccountIDMask = 0xFFFFFFFF
+	steamIDInstanceMask  = 0x000FFFFF
+	steamIDTypeMask      = 0xF
+	steamIDInstanceShift = 32
+	steamIDTypeShift     = 52
+	steamIDUniverseShift = 56
+)
+
+// NewSteamID 由各字段组成 SteamID，超出位宽的部分被截断
+func NewSteamID(universe Universe, accountType AccountType, instance, accountID uint32) SteamID {
+	return SteamID(uint64(universe)<<steamIDUniverseShift |
+		uint64(accountType&steamIDTypeMask)<<steamIDTypeShift |
+		uint64(instance&steamIDInstanceMask)<<steamIDInstanceShift |
+		uint64(accountID))
+}
+
+// NewIndividualSteamID 返回公共宇宙中普通用户的 SteamID
+func NewIndividualSteamID(accountID uint32) SteamID {
+	return NewSteamID(UniversePublic, AccountTypeIndividual, InstanceDesktop, accountID)
+}
+
+// AccountID 返回账号 ID（低 32 位）
+func (id SteamID) AccountID() uint32 {
+	return uint32(uint64(id) & steamIDAccountIDMask)
+}
+
+// Instance 返回实例
+func (id SteamID) Instance() uint32 {
+	return uint32(uint64(id)>>steamIDInstanceShift) & steamIDInstanceMask
+}
+
+// AccountType 返回账号类型
+func (id SteamID) AccountType() AccountType {
+	return AccountType(uint64(id)>>steamIDTypeShift) & steamIDTypeMask
+}
+
+// Universe 返回宇宙
+func (id SteamID) Universe() Universe {
+	return Universe(uint64(id) >> steamIDUniverseShift)
+}
+
+// IsValid 按 Steamworks CSteamID::IsValid 的规则检查 SteamID 是否有效
+func (id SteamID) IsValid() bool {
+	accountType := id.AccountType()
+	if accountType <= AccountTypeInvalid || accountType > AccountTypeAnonUser {
+		return false
+	}
+	universe := id.Universe()
+	if universe <= UniverseInvalid || universe > UniverseDev {
+		return false
+	}
+
+	switch accountType {
+	case AccountTypeIndividual:
+		// 普通用户的账号 ID 不能为 0，实例不能超过 Web
+		return id.AccountID() != 0 && id.Instance() <= InstanceWeb
+	case AccountTypeClan:
+		return id.AccountID() != 0 && id.Instance() == 0
+	case AccountTypeGameServer:
+		return id.AccountID() != 0
+	default:
+		return true
+	}
+}
+
+// String 返回十进制的 64 位表示
+func (id SteamID) String() string {
+	return strconv.FormatUint(uint64(id), 10)
+}
+
+// Steam2 返回 Steam2 格式（STEAM_X:Y:Z）
+// 公共宇宙按传统写作 STEAM_0
+func (id SteamID) Steam2() string {
+	universe := id.Universe()
+	if universe == UniversePublic {
+		universe = 0
+	}
+	accountID := id.AccountID()
+	return fmt.Sprintf("STEAM_%d:%d:%d", universe, accountID&1, accountID>>1)
+}
+
+// Steam3 返回 Steam3 格式（如 [U:1:2469]）
+func (id SteamID) Steam3() string {
+	accountType := id.AccountType()
+	instance := id.Instance()
+
+	letter := steam3Letters[accountType]
+	if letter == 0 {
+		letter = 'i'
+	}
+	if accountType == AccountTypeChat {
+		switch {
+		case instance&InstanceFlagClan != 0:
+			letter = 'c'
+		case instance&InstanceFlagLobby != 0:
+			letter = 'L'
+		}
+	}
+
+	s := fmt.Sprintf("[%c:%d:%d", letter, id.Universe(), id.AccountID())
+	if accountType == AccountTypeAnonGameServer || accountType == AccountTypeMultiseat ||
+		(accountType == AccountTypeIndividual && instance != InstanceDesktop) {
+		s += fmt.Sprintf(":%d", instance)
+	}
+	return s + "]"
+}
+
+// ProfileURL 返回 Steam 社区个人资料链接
+func (id SteamID) ProfileURL() string {
+	return communityProfilesURL + id.String()
+}
+
+// steam3Letters 是各账号类型在 Steam3 格式中的字母
+var steam3Letters = map[AccountType]byte{
+	AccountTypeInvalid:        'I',
+	AccountTypeIndividual:     'U',
+	AccountTypeMultiseat:      'M',
+	AccountTypeGameServer:     'G',
+	AccountTypeAnonGameServer: 'A',
+	AccountTypePending:        'P',
+	AccountTypeContentServer:  'C',
+	AccountTypeClan:           'g',
+	AccountTypeChat:           'T',
+	AccountTypeAnonUser:       'a',
+}
+
+// communityProfilesURL 是社区个人资料链接的前缀
+const communityProfilesURL = "https://steamcommunity.com/profiles/"
+
+// ParseSteamID 解析 SteamID
+// 支持格式：
+//   - "76561197960290418" - 64 位十进制
+//   - "STEAM_0:1:1234" - Steam2
+//   - "[U:1:2469]"、"[U:1:2469:2]" - Steam3
+//   - "https://steamcommunity.com/profiles/76561197960290418" - 社区个人资料链接（也接受 Steam3 路径）
+//
+// 自定义链接（/id/name）需要联网解析，不支持
+func ParseSteamID(s string) (SteamID, error) {
+	s = strings.TrimSpace(s)
+	switch {
+	case strings.HasPrefix(s, "STEAM_"):
+		return parseSteam2(s)
+	case strings.HasPrefix(s, "["):
+		return parseSteam3(s)
+	case strings.Contains(s, "steamcommunity.com/"):
+		return parseProfileURL(s)
+	}
+
+	v, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, invalidSteamID(s)
+	}
+	return SteamID(v), nil
+}
+
+// invalidSteamID 返回包装了 ErrInvalidSteamID 的错误
+func invalidSteamID(s string) error {
+	return fmt.Errorf("%w: %q", ErrInvalidSteamID, s)
+}
+
+// parseSteam2 解析 STEAM_X:Y:Z，X 为 0 时视为公共宇宙
+func parseSteam2(s string) (SteamID, error) {
+	parts := strings.Split(strings.TrimPrefix(s, "STEAM_"), ":")
+	if len(parts) != 3 {
+		return 0, invalidSteamID(s)
+	}
+	universe, err1 := strconv.ParseUint(parts[0], 10, 8)
+	y, err2 := strconv.ParseUint(parts[1], 10, 1)
+	z, err3 := strconv.ParseUint(parts[2], 10, 31)
+	if err1 != nil || err2 != nil || err3 != nil {
+		return 0, invalidSteamID(s)
+	}
+	if universe == 0 {
+		universe = uint64(UniversePublic)
+	}
+	return NewSteamID(Universe(universe), AccountTypeIndividual, InstanceDesktop, uint32(z<<1|y)), nil
+}
+
+// parseSteam3 解析 [L:U:A] 或 [L:U:A:I]
+func parseSteam3(s string) (SteamID, error) {
+	if !strings.HasSuffix(s, "]") {
+		return 0, invalidSteamID(s)
+	}
+	parts := strings.Split(s[1:len(s)-1], ":")
+	if (len(parts) != 3 && len(parts) != 4) || len(parts[0]) != 1 {
+		return 0, invalidSteamID(s)
+	}
+
+	universe, err1 := strconv.ParseUint(parts[1], 10, 8)
+	accountID, err2 := strconv.ParseUint(parts[2], 10, 32)
+	if err1 != nil || err2 != nil {
+		return 0, invalidSteamID(s)
+	}
+
+	letter := parts[0][0]
+	var accountType AccountType
+	var instance uint32
+	switch letter {
+	case 'c':
+		accountType, instance = AccountTypeChat, InstanceFlagClan
+	case 'L':
+		accountType, instance = AccountTypeChat, InstanceFlagLobby
+	default:
+		found := false
+		for t, l := range steam3Letters {
+			if l == letter {
+				accountType, found = t, true
+				break
+			}
+		}
+		if !found {
+			return 0, invalidSteamID(s)
+		}
+		if accountType == AccountTypeIndividual {
+			instance = InstanceDesktop
+		}
+	}
+
+	if len(parts) == 4 {
+		v, err := strconv.ParseUint(parts[3], 10, 20)
+		if err != nil {
+			return 0, invalidSteamID(s)
+		}
+		instance = uint32(v)
+	}
+	return NewSteamID(Universe(universe), accountType, instance, uint32(accountID)), nil
+}
+
+// parseProfileURL 解析社区个人资料链接
+func parseProfileURL(s string) (SteamID, error) {
+	_, rest, _ := strings.Cut(s, "steamcommunity.com/")
+	path, ok := strings.CutPrefix(rest, "profiles/")
+	if !ok {
+		return 0, invalidSteamID(s)
+	}
+	path, _, _ = strings.Cut(path, "?")
+	path = strings.TrimSuffix(path, "/")
+	if path == "" || strings.Contains(path, "/") {
+		return 0, invalidSteamID(s)
+	}
+	if strings.HasPrefix(path, "[") {
+		return parseSteam3(path)
+	}
+	v, err := strconv.ParseUint(path, 10, 64)
+	if err != nil {
+		return 0, invalidSteamID(s)
+	}
+	return SteamID(v), nil
+}
+
+// MarshalText 实现 encoding.TextMarshaler，输出 64 位十进制
+func (id SteamID) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+// UnmarshalText 实现 encoding.TextUnmarshaler，接受 ParseSteamID 支持的所有格式
+func (id *SteamID) UnmarshalText(text []byte) error {
+	v, err := ParseSteamID(string(text))
+	if err != nil {
+		return err
+	}
+	*id = v
+	return nil
+}
+
+// MarshalJSON 实现 json.Marshaler
+// 输出为字符串，避免 JavaScript 等使用双精度浮点数的环境丢失精度
+func (id SteamID) MarshalJSON() ([]byte, error) {
+	return json.Marshal(id.String())
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler，同时接受字符串和数字
+func (id *SteamID) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		return nil
+	}
+	var s string
+	if len(b) > 0 && b[0] == '"' {
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+	} else {
+		s = string(b)
+	}
+	return id.UnmarshalText([]byte(s))
+}
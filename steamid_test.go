@@ -0,0 +1,167 @@
+package steamkit
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestSteamID_Fields(t *testing.T) {
+	id := SteamID(76561197960287930)
+	if id.AccountID() != 22202 {
+		t.Errorf("AccountID() = %d, want 22202", id.AccountID())
+	}
+	if id.Instance() != InstanceDesktop {
+		t.Errorf("Instance() = %d, want %d", id.Instance(), InstanceDesktop)
+	}
+	if id.AccountType() != AccountTypeIndividual {
+		t.Errorf("AccountType() = %v, want Individual", id.AccountType())
+	}
+	if id.Universe() != UniversePublic {
+		t.Errorf("Universe() = %v, want Public", id.Universe())
+	}
+	if !id.IsValid() {
+		t.Error("IsValid() = false, want true")
+	}
+	if got := NewIndividualSteamID(22202); got != id {
+		t.Errorf("NewIndividualSteamID() = %d, want %d", got, id)
+	}
+}
+
+func TestSteamID_IsValid(t *testing.T) {
+	tests := []struct {
+		name string
+		id   SteamID
+		want bool
+	}{
+		{"Zero", 0, false},
+		{"Individual", NewIndividualSteamID(1), true},
+		{"IndividualZeroAccount", NewIndividualSteamID(0), false},
+		{"InvalidUniverse", NewSteamID(UniverseInvalid, AccountTypeIndividual, InstanceDesktop, 1), false},
+		{"InvalidType", NewSteamID(UniversePublic, AccountType(11), 0, 1), false},
+		{"ClanWithInstance", NewSteamID(UniversePublic, AccountTypeClan, 1, 1), false},
+		{"AnonGameServer", NewSteamID(UniversePublic, AccountTypeAnonGameServer, 5, 0), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.id.IsValid(); got != tt.want {
+				t.Errorf("IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSteamID_Format(t *testing.T) {
+	tests := []struct {
+		id     SteamID
+		steam2 string
+		steam3 string
+	}{
+		{76561197960287930, "STEAM_0:0:11101", "[U:1:22202]"},
+		{NewIndividualSteamID(2469), "STEAM_0:1:1234", "[U:1:2469]"},
+		{NewSteamID(UniversePublic, AccountTypeIndividual, InstanceConsole, 2469), "STEAM_0:1:1234", "[U:1:2469:2]"},
+		{NewSteamID(UniversePublic, AccountTypeClan, 0, 4), "STEAM_0:0:2", "[g:1:4]"},
+		{NewSteamID(UniversePublic, AccountTypeAnonGameServer, 7, 100), "STEAM_0:0:50", "[A:1:100:7]"},
+		{NewSteamID(UniversePublic, AccountTypeChat, InstanceFlagLobby, 9), "STEAM_0:1:4", "[L:1:9]"},
+	}
+	for _, tt := range tests {
+		if got := tt.id.Steam2(); got != tt.steam2 {
+			t.Errorf("Steam2(%d) = %q, want %q", tt.id, got, tt.steam2)
+		}
+		if got := tt.id.Steam3(); got != tt.steam3 {
+			t.Errorf("Steam3(%d) = %q, want %q", tt.id, got, tt.steam3)
+		}
+		// Steam3 格式可以无损往返
+		if got, err := ParseSteamID(tt.steam3); err != nil || got != tt.id {
+			t.Errorf("ParseSteamID(%q) = %d, %v, want %d", tt.steam3, got, err, tt.id)
+		}
+	}
+
+	if got := SteamID(76561197960287930).ProfileURL(); got != "https://steamcommunity.com/profiles/76561197960287930" {
+		t.Errorf("ProfileURL() = %q", got)
+	}
+}
+
+func TestParseSteamID(t *testing.T) {
+	want := NewIndividualSteamID(2469)
+	inputs := []string{
+		"76561197960268197",
+		"STEAM_0:1:1234",
+		"STEAM_1:1:1234",
+		"[U:1:2469]",
+		" [U:1:2469] ",
+		"https://steamcommunity.com/profiles/76561197960268197",
+		"https://steamcommunity.com/profiles/76561197960268197/",
+		"steamcommunity.com/profiles/[U:1:2469]",
+	}
+	for _, s := range inputs {
+		got, err := ParseSteamID(s)
+		if err != nil {
+			t.Errorf("ParseSteamID(%q) error = %v", s, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("ParseSteamID(%q) = %d, want %d", s, got, want)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"abc",
+		"-1",
+		"STEAM_0:2:1234",
+		"STEAM_0:1",
+		"[U:1:2469",
+		"[X:1:2469]",
+		"[U:1]",
+		"https://steamcommunity.com/id/gabelogannewell",
+	}
+	for _, s := range invalid {
+		if _, err := ParseSteamID(s); !errors.Is(err, ErrInvalidSteamID) {
+			t.Errorf("ParseSteamID(%q) error = %v, want ErrInvalidSteamID", s, err)
+		}
+	}
+}
+
+func TestSteamID_Marshal(t *testing.T) {
+	type profile struct {
+		ID SteamID `json:"id"`
+	}
+
+	b, err := json.Marshal(profile{ID: 76561197960287930})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if string(b) != `{"id":"76561197960287930"}` {
+		t.Errorf("json.Marshal() = %s", b)
+	}
+
+	for _, in := range []string{
+		`{"id":"76561197960287930"}`,
+		`{"id":76561197960287930}`,
+		`{"id":"[U:1:22202]"}`,
+		`{"id":"STEAM_0:0:11101"}`,
+	} {
+		var p profile
+		if err := json.Unmarshal([]byte(in), &p); err != nil {
+			t.Errorf("json.Unmarshal(%s) error = %v", in, err)
+			continue
+		}
+		if p.ID != 76561197960287930 {
+			t.Errorf("json.Unmarshal(%s) = %d", in, p.ID)
+		}
+	}
+
+	var p profile
+	if err := json.Unmarshal([]byte(`{"id":"bogus"}`), &p); !errors.Is(err, ErrInvalidSteamID) {
+		t.Errorf("json.Unmarshal(bogus) error = %v, want ErrInvalidSteamID", err)
+	}
+
+	var id SteamID
+	if err := id.UnmarshalText([]byte("[U:1:22202]")); err != nil || id != 76561197960287930 {
+		t.Errorf("UnmarshalText() = %d, %v", id, err)
+	}
+	if text, _ := id.MarshalText(); string(text) != "76561197960287930" {
+		t.Errorf("MarshalText() = %s", text)
+	}
+}
@@ -3,6 +3,8 @@ package steamkit
 
 import (
 	"fmt"
+	"os"
+	"strconv"
 
 	"github.com/guowei-gong/steamkit-go/internal/purego"
 )
@@ -19,43 +21,213 @@ const (
 
 // RestartAppIfNecessary 检查是否需要通过 Steam 重启应用
 // 如果返回 true，应用应该立即退出
+// 应在 Init 之前调用，尚未加载 Steam 库时按默认路径加载；库加载失败时返回 false，
+// 需要自定义库路径或获取错误时使用 RestartAppIfNecessaryWithOptions
 func RestartAppIfNecessary(appID uint32) bool {
-	return purego.CallRestartAppIfNecessary(appID)
+	restart, _ := RestartAppIfNecessaryWithOptions(appID, InitOptions{})
+	return restart
+}
+
+// RestartAppIfNecessaryWithOptions 与 RestartAppIfNecessary 相同，
+// 尚未加载 Steam 库时按 opts 的 LibPath 和 SearchDirs 加载（与 InitWithOptions 相同），其他选项被忽略；
+// 库加载失败或不支持该函数时返回错误
+func RestartAppIfNecessaryWithOptions(appID uint32, opts InitOptions) (bool, error) {
+	if !purego.Loaded() {
+		if err := purego.InitWithOptions(loadOptions(opts)); err != nil {
+			return false, fmt.Errorf("failed to initialize purego: %w", err)
+		}
+	}
+	if err := purego.Require("SteamAPI_RestartAppIfNecessary"); err != nil {
+		return false, err
+	}
+	return purego.CallRestartAppIfNecessary(appID), nil
+}
+
+// ErrNotInitialized 表示 Steam API 尚未初始化或已经关闭
+var ErrNotInitialized = purego.ErrNotInitialized
+
+// ErrShuttingDown 表示在回调循环中调用 Init 时，最后一次 Shutdown 正在进行
+var ErrShuttingDown = purego.ErrShuttingDown
+
+// LibPathEnv 是指定 Steam 库路径的环境变量（STEAMKIT_LIB_PATH），可以是库文件或所在目录
+const LibPathEnv = purego.LibPathEnv
+
+// LoadError 表示 Steam 库加载失败，Attempts 列出每个尝试的路径及失败原因
+type LoadError = purego.LoadError
+
+// LoadAttempt 记录一次库加载尝试
+type LoadAttempt = purego.LoadAttempt
+
+// InitOptions 是 InitWithOptions 的选项
+type InitOptions struct {
+	// LibPath 是 Steam 库文件或所在目录，设置后只尝试该路径
+	// 为空时依次使用 STEAMKIT_LIB_PATH 环境变量、可执行文件目录、SearchDirs 和平台默认路径
+	LibPath string
+
+	// SearchDirs 是额外的库查找目录，相对路径以可执行文件所在目录为基准
+	SearchDirs []string
+
+	// AppID 不为 0 时设置 SteamAppId 和 SteamGameId 环境变量，
+	// 使未通过 Steam 启动的程序（开发环境）能够初始化
+	AppID uint32
+
+	// WriteAppIDFile 为 true 时同时在当前目录写入 steam_appid.txt（需要 AppID）
+	// 仅用于开发，发布版本不应包含该文件
+	WriteAppIDFile bool
 }
 
 // Init 初始化 Steam API
 // 必须在使用任何其他 Steam API 之前调用
+// Init 和 Shutdown 采用引用计数，可以被多个组件分别调用，每次成功的 Init 都需要对应一次 Shutdown
 func Init() error {
+	return InitWithOptions(InitOptions{})
+}
+
+// InitWithOptions 按 opts 加载 Steam 库并初始化 Steam API
+// 已经初始化时只增加引用计数，opts 被忽略
+// 最后一次 Shutdown 进行中时等待其完成；在正在停止的回调循环的回调中调用时不等待，返回 ErrShuttingDown
+func InitWithOptions(opts InitOptions) error {
+	initFn := func() error {
+		return initSteamAPI(opts)
+	}
+	if onCallbackLoop() {
+		// 最后一次 Shutdown 会等待回调循环退出，这里等待 Shutdown 完成会互相等待
+		return purego.TryAcquire(initFn)
+	}
+	return purego.Acquire(initFn)
+}
+
+// initSteamAPI 执行实际的初始化
+func initSteamAPI(opts InitOptions) error {
+	if err := applyAppID(opts); err != nil {
+		return err
+	}
+
 	// 初始化 purego 绑定层
-	if err := purego.Init(); err != nil {
+	if err := purego.InitWithOptions(loadOptions(opts)); err != nil {
 		return fmt.Errorf("failed to initialize purego: %w", err)
 	}
 
-	// 调用 SteamAPI_InitFlat
-	result, errMsg := purego.CallInitFlat()
+	// 调用 SteamInternal_SteamAPI_Init（或 SteamAPI_InitFlat）
+	result, errMsg := purego.CallSteamAPIInit()
 	if ESteamAPIInitResult(result) != ESteamAPIInitResult_OK {
 		if errMsg != "" {
-			return fmt.Errorf("SteamAPI_InitFlat failed: %s (code: %d)", errMsg, result)
+			return fmt.Errorf("SteamAPI_Init failed: %s (code: %d)", errMsg, result)
+		}
+		return fmt.Errorf("SteamAPI_Init failed with code: %d", result)
+	}
+
+	// 启用手动回调分发，回调通过 RunCallbacks 分发给 On 注册的订阅者
+	// 库不支持手动分发时回调不可用，可通过 Capabilities 检查
+	if purego.Supported(manualDispatchSymbols...) {
+		purego.CallManualDispatchInit()
+		manualDispatch.Store(true)
+	}
+
+	return nil
+}
+
+// loadOptions 返回 opts 中与加载 Steam 库相关的选项
+func loadOptions(opts InitOptions) purego.LoadOptions {
+	return purego.LoadOptions{
+		LibPath:    opts.LibPath,
+		SearchDirs: opts.SearchDirs,
+	}
+}
+
+// InterfaceVersion 描述一个接口协商后选定的版本
+type InterfaceVersion = purego.InterfaceVersion
+
+// InterfaceVersions 返回 Init 时为每个接口选定的版本
+// 在加载的 Steam 库中按从新到旧的顺序查找兼容版本，未初始化时返回 nil
+func InterfaceVersions() []InterfaceVersion {
+	return purego.SelectedInterfaceVersions()
+}
+
+// appIDFile 是 Steam API 在开发环境中读取 AppID 的文件
+const appIDFile = "steam_appid.txt"
+
+// applyAppID 按选项设置 AppID 环境变量并写入 steam_appid.txt
+func applyAppID(opts InitOptions) error {
+	if opts.AppID == 0 {
+		if opts.WriteAppIDFile {
+			return fmt.Errorf("WriteAppIDFile requires AppID")
+		}
+		return nil
+	}
+
+	id := strconv.FormatUint(uint64(opts.AppID), 10)
+	for _, key := range []string{"SteamAppId", "SteamGameId"} {
+		if err := os.Setenv(key, id); err != nil {
+			return fmt.Errorf("failed to set %s: %w", key, err)
 		}
-		return fmt.Errorf("SteamAPI_InitFlat failed with code: %d", result)
 	}
 
+	if opts.WriteAppIDFile {
+		if err := os.WriteFile(appIDFile, []byte(id+"\n"), 0o644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", appIDFile, err)
+		}
+	}
 	return nil
 }
 
 // Shutdown 关闭 Steam API
 // 应该在程序退出前调用
+// 只有最后一次 Shutdown（与 Init 次数匹配）才会真正关闭，之后获取的接口句柄全部失效
+// 未初始化时调用没有任何效果
+// 在 StartCallbackLoop 的回调中调用时，关闭推迟到当前帧结束、循环退出之后执行
 func Shutdown() {
-	purego.CallShutdown()
+	if deferShutdownToCallbackLoop() {
+		return
+	}
+	// 原子地判断是否为最后一次关闭，是则保留关闭，期间其他 Init / Shutdown 等待
+	if !purego.BeginRelease() {
+		return
+	}
+	// 关闭前先停止后台回调循环，确保关闭期间没有回调在执行
+	// 此时未持有生命周期锁，循环中的订阅者调用 Shutdown 会被推迟，调用 Init 返回 ErrShuttingDown
+	stopCallbackLoop()
+	purego.EndRelease(shutdownSteamAPI)
+}
+
+// shutdownNative 调用原生 SteamAPI_Shutdown，测试时可替换
+var shutdownNative = purego.CallShutdown
+
+// shutdownSteamAPI 执行实际的关闭
+func shutdownSteamAPI() {
+	manualDispatch.Store(false)
+	globalCallResults.failAll(ErrShutdown)
+	shutdownNative()
 }
 
 // RunCallbacks 处理 Steam 回调
 // 应该在主循环中定期调用（建议每 10-50ms）
+// 取出所有待处理的回调，解码后分发给 On 和 OnRaw 注册的订阅者
+// 库不支持手动分发时回退到 SteamAPI_RunCallbacks，此时订阅者收不到回调
+// 订阅者的 panic 会传递给调用方；不要在 StartCallbackLoop 运行期间调用
 func RunCallbacks() {
-	purego.CallRunCallbacks()
+	runSteamCallbacks(nil)
+}
+
+// runSteamCallbacks 分发一帧 Steam 回调，返回分发给订阅者的回调数
+func runSteamCallbacks(onPanic func(CallbackID, any)) int {
+	if manualDispatch.Load() {
+		return pumpCallbacks(onPanic)
+	}
+	if purego.Initialized() && purego.Supported("SteamAPI_RunCallbacks") {
+		purego.CallRunCallbacks()
+	}
+	return 0
 }
 
 // GetSteamID 获取当前用户的 SteamID
-func GetSteamID() uint64 {
-	return purego.CallGetSteamID()
+// 未初始化时返回 ErrNotInitialized
+func GetSteamID() (SteamID, error) {
+	if err := purego.CheckInitialized(); err != nil {
+		return 0, err
+	}
+	if err := purego.Require("SteamAPI_ISteamUser_GetSteamID"); err != nil {
+		return 0, err
+	}
+	return SteamID(purego.CallGetSteamID()), nil
 }
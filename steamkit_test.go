@@ -0,0 +1,69 @@
+package steamkit
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestNotInitialized(t *testing.T) {
+	if _, err := GetSteamID(); !errors.Is(err, ErrNotInitialized) {
+		t.Errorf("GetSteamID() error = %v, want ErrNotInitialized", err)
+	}
+	// 未初始化时 RunCallbacks 和 Shutdown 不调用原生函数
+	RunCallbacks()
+	Shutdown()
+}
+
+func TestApplyAppID(t *testing.T) {
+	dir := t.TempDir()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	t.Setenv("SteamAppId", "")
+	t.Setenv("SteamGameId", "")
+
+	if err := applyAppID(InitOptions{AppID: 480, WriteAppIDFile: true}); err != nil {
+		t.Fatalf("applyAppID() error = %v", err)
+	}
+	if got := os.Getenv("SteamAppId"); got != "480" {
+		t.Errorf("SteamAppId = %q, want %q", got, "480")
+	}
+	if got := os.Getenv("SteamGameId"); got != "480" {
+		t.Errorf("SteamGameId = %q, want %q", got, "480")
+	}
+	data, err := os.ReadFile(appIDFile)
+	if err != nil {
+		t.Fatalf("steam_appid.txt not written: %v", err)
+	}
+	if string(data) != "480\n" {
+		t.Errorf("steam_appid.txt = %q, want %q", data, "480\n")
+	}
+}
+
+func TestApplyAppID_NoAppID(t *testing.T) {
+	if err := applyAppID(InitOptions{}); err != nil {
+		t.Errorf("applyAppID() error = %v, want nil", err)
+	}
+	if err := applyAppID(InitOptions{WriteAppIDFile: true}); err == nil {
+		t.Error("applyAppID() with WriteAppIDFile and no AppID should fail")
+	}
+}
+
+func TestRestartAppIfNecessaryWithOptions_LoadError(t *testing.T) {
+	// 库加载失败时返回错误，而不是静默地返回 false
+	restart, err := RestartAppIfNecessaryWithOptions(480, InitOptions{LibPath: t.TempDir()})
+	if restart {
+		t.Error("RestartAppIfNecessaryWithOptions() = true, want false")
+	}
+	var loadErr *LoadError
+	if !errors.As(err, &loadErr) {
+		t.Errorf("RestartAppIfNecessaryWithOptions() error = %v, want *LoadError", err)
+	}
+}
@@ -1,7 +1,10 @@
 package steamnet
 
 import (
+	"encoding/binary"
 	"sync"
+
+	"github.com/guowei-gong/steamkit-go/internal/purego"
 )
 
 // ConnectionStatusChangedCallback 是连接状态变化的回调函数类型
@@ -47,19 +50,105 @@ func ClearConnectionCallback(conn Connection) {
 
 // DispatchConnectionStatusChanged 分发连接状态变化回调
 // 这个函数由内部调用，用户不应直接调用
+// 回调在释放锁之后执行，因此可以在回调中调用 SetConnectionCallback / ClearConnectionCallback
 func DispatchConnectionStatusChanged(info *ConnectionStatusChangedInfo) {
 	globalCallbackManager.mu.RLock()
-	defer globalCallbackManager.mu.RUnlock()
+	callback, ok := globalCallbackManager.connectionCallbacks[info.Connection]
+	global := globalCallbackManager.globalCallback
+	globalCallbackManager.mu.RUnlock()
 
-	// 先尝试调用特定连接的回调
-	if callback, ok := globalCallbackManager.connectionCallbacks[info.Connection]; ok {
+	// 先尝试调用特定连接的回调，否则调用全局回调
+	if ok {
 		callback(info)
 		return
 	}
+	if global != nil {
+		global(info)
+	}
+}
+
+// SteamNetConnectionStatusChangedCallback_t 结构体布局：
+//
+//	offset 0: HSteamNetConnection m_hConn (uint32)
+//	offset statusChangedInfoOffset: SteamNetConnectionInfo_t m_info (696 bytes)
+//	offset statusChangedInfoOffset+696: ESteamNetworkingConnectionState m_eOldState (int32)
+//
+// m_info 的偏移与回调结构体的打包方式有关，见 callbacks_unix.go 和 callbacks_windows.go
+const statusChangedSize = statusChangedInfoOffset + connectionInfoSize + 4
+
+// parseConnectionStatusChanged 解析 SteamNetConnectionStatusChangedCallback_t 结构体
+func parseConnectionStatusChanged(b []byte) *ConnectionStatusChangedInfo {
+	info := parseConnectionInfo(b[statusChangedInfoOffset : statusChangedInfoOffset+connectionInfoSize])
+
+	return &ConnectionStatusChangedInfo{
+		Connection: Connection(binary.LittleEndian.Uint32(b[0:])),
+		Identity:   info.Identity,
+		OldState:   ConnectionState(int32(binary.LittleEndian.Uint32(b[statusChangedInfoOffset+connectionInfoSize:]))),
+		NewState:   info.State,
+		EndReason:  info.EndReason,
+		EndDebug:   info.EndDebug,
+		Info:       *info,
+	}
+}
 
-	// 否则调用全局回调
-	if globalCallbackManager.globalCallback != nil {
-		globalCallbackManager.globalCallback(info)
+// Err 在连接被对方关闭或本地检测到问题时返回描述结束原因的 *Error，其他状态返回 nil
+// 返回的错误包装 ErrNotConnected，EndReason 为原生结束原因，超时原因的 Timeout() 为 true
+func (info *ConnectionStatusChangedInfo) Err() error {
+	if info.NewState != ConnectionStateClosedByPeer && info.NewState != ConnectionStateProblemDetectedLocally {
+		return nil
+	}
+	return &Error{
+		Op:         "connection closed",
+		Connection: info.Connection,
+		Identity:   info.Identity,
+		EndReason:  info.EndReason,
+		Err:        ErrNotConnected,
 	}
 }
 
+// onConnectionStatusChanged 是注册给原生层的连接状态变化回调
+// 在 ISteamNetworkingSockets::RunCallbacks 期间由原生层调用
+func onConnectionStatusChanged(pInfo uintptr) {
+	b := nativeBytes(pInfo, statusChangedSize)
+	if b == nil {
+		return
+	}
+	DispatchConnectionStatusChanged(parseConnectionStatusChanged(b))
+}
+
+var (
+	statusChangedHookOnce sync.Once
+	statusChangedHookPtr  uintptr
+
+	// statusChangedHookMu 保护 statusChangedHookInstalled，每次初始化只注册一次，Shutdown 时重置
+	statusChangedHookMu        sync.Mutex
+	statusChangedHookInstalled bool
+)
+
+func init() {
+	purego.OnShutdown(func() {
+		statusChangedHookMu.Lock()
+		statusChangedHookInstalled = false
+		statusChangedHookMu.Unlock()
+	})
+}
+
+// installConnectionStatusChangedHook 将连接状态变化回调注册为全局配置
+// 配置值在连接创建时被继承，因此必须在创建任何连接或监听套接字之前调用
+// 每次初始化只注册一次，不会覆盖之后用户通过 SetGlobalConfigValuePtr 设置的值
+func installConnectionStatusChangedHook(utils uintptr) bool {
+	statusChangedHookMu.Lock()
+	defer statusChangedHookMu.Unlock()
+
+	if statusChangedHookInstalled {
+		return true
+	}
+	if utils == 0 || !purego.Supported(utilsSymbolPrefix+"SetGlobalConfigValuePtr") {
+		return false
+	}
+	statusChangedHookOnce.Do(func() {
+		statusChangedHookPtr = purego.NewCallback(onConnectionStatusChanged)
+	})
+	statusChangedHookInstalled = purego.CallSetGlobalConfigValuePtr(utils, int32(ConfigCallbackConnectionStatusChanged), statusChangedHookPtr)
+	return statusChangedHookInstalled
+}
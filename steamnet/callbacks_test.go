@@ -1,8 +1,10 @@
 package steamnet
 
 import (
+	"encoding/binary"
 	"sync"
 	"testing"
+	"unsafe"
 )
 
 // 测试全局回调设置
@@ -243,3 +245,94 @@ func TestNoCallback(t *testing.T) {
 
 	// 如果没有崩溃，测试通过
 }
+
+// buildStatusChanged 构造一个 SteamNetConnectionStatusChangedCallback_t
+func buildStatusChanged(conn Connection, oldState, newState ConnectionState) []byte {
+	b := make([]byte, statusChangedSize)
+	binary.LittleEndian.PutUint32(b[0:], uint32(conn))
+
+	info := b[statusChangedInfoOffset:]
+	binary.LittleEndian.PutUint32(info[0:], nativeIdentityTypeSteamID)
+	binary.LittleEndian.PutUint32(info[4:], 8)
+	binary.LittleEndian.PutUint64(info[8:], 76561198000000000)
+	binary.LittleEndian.PutUint64(info[136:], uint64(99))
+	binary.LittleEndian.PutUint32(info[176:], uint32(newState))
+	binary.LittleEndian.PutUint32(info[180:], 2001)
+	copy(info[184:], "peer closed")
+
+	binary.LittleEndian.PutUint32(b[statusChangedInfoOffset+connectionInfoSize:], uint32(oldState))
+	return b
+}
+
+// 测试解析 SteamNetConnectionStatusChangedCallback_t
+func TestParseConnectionStatusChanged(t *testing.T) {
+	b := buildStatusChanged(Connection(5), ConnectionStateConnected, ConnectionStateClosedByPeer)
+
+	info := parseConnectionStatusChanged(b)
+
+	if info.Connection != Connection(5) {
+		t.Errorf("Connection = %v, want 5", info.Connection)
+	}
+	if info.OldState != ConnectionStateConnected {
+		t.Errorf("OldState = %v, want %v", info.OldState, ConnectionStateConnected)
+	}
+	if info.NewState != ConnectionStateClosedByPeer {
+		t.Errorf("NewState = %v, want %v", info.NewState, ConnectionStateClosedByPeer)
+	}
+	if !info.Identity.Equal(NewIdentityFromSteamID(76561198000000000)) {
+		t.Errorf("Identity = %v, want SteamID:76561198000000000", info.Identity)
+	}
+	if info.EndReason != 2001 || info.EndDebug != "peer closed" {
+		t.Errorf("EndReason/EndDebug = (%d, %q), want (2001, %q)", info.EndReason, info.EndDebug, "peer closed")
+	}
+	if code, ok := info.EndReason.AppCode(); !info.EndReason.IsAppException() || !ok || code != 1 {
+		t.Errorf("EndReason = %v, want AppException(1)", info.EndReason)
+	}
+	if info.Info.UserData != 99 || info.Info.State != ConnectionStateClosedByPeer {
+		t.Errorf("Info = {UserData: %d, State: %v}, want {99, %v}", info.Info.UserData, info.Info.State, ConnectionStateClosedByPeer)
+	}
+}
+
+// 测试原生回调入口分发到 Go 回调
+func TestOnConnectionStatusChanged(t *testing.T) {
+	b := buildStatusChanged(Connection(6), ConnectionStateConnecting, ConnectionStateConnected)
+
+	var got *ConnectionStatusChangedInfo
+	SetConnectionCallback(Connection(6), func(info *ConnectionStatusChangedInfo) {
+		got = info
+	})
+	defer ClearConnectionCallback(Connection(6))
+
+	onConnectionStatusChanged(uintptr(unsafe.Pointer(&b[0])))
+
+	if got == nil {
+		t.Fatal("connection callback was not called")
+	}
+	if got.OldState != ConnectionStateConnecting || got.NewState != ConnectionStateConnected {
+		t.Errorf("states = (%v, %v), want (%v, %v)", got.OldState, got.NewState, ConnectionStateConnecting, ConnectionStateConnected)
+	}
+
+	// 空指针应被忽略
+	onConnectionStatusChanged(0)
+}
+
+// 测试回调中可以修改回调注册而不会死锁
+func TestDispatchConnectionStatusChanged_Reentrant(t *testing.T) {
+	conn := Connection(77)
+	defer SetConnectionStatusChangedCallback(nil)
+
+	calls := 0
+	SetConnectionCallback(conn, func(info *ConnectionStatusChangedInfo) {
+		calls++
+		ClearConnectionCallback(info.Connection)
+		SetConnectionStatusChangedCallback(func(*ConnectionStatusChangedInfo) {})
+	})
+
+	info := &ConnectionStatusChangedInfo{Connection: conn, NewState: ConnectionStateClosedByPeer}
+	DispatchConnectionStatusChanged(info)
+	DispatchConnectionStatusChanged(info)
+
+	if calls != 1 {
+		t.Errorf("connection callback called %d times, want 1", calls)
+	}
+}
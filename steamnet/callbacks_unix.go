@@ -0,0 +1,7 @@
+//go:build !windows
+
+package steamnet
+
+// statusChangedInfoOffset 是 m_info 在 SteamNetConnectionStatusChangedCallback_t 中的偏移
+// Linux 和 macOS 上回调结构体使用 #pragma pack(4)
+const statusChangedInfoOffset = 4
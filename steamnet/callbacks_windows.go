@@ -0,0 +1,7 @@
+//go:build windows
+
+package steamnet
+
+// statusChangedInfoOffset 是 m_info 在 SteamNetConnectionStatusChangedCallback_t 中的偏移
+// Windows 上回调结构体使用 #pragma pack(8)，m_info 含 int64 字段，按 8 字节对齐
+const statusChangedInfoOffset = 8
@@ -0,0 +1,515 @@
+package steamnet
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"runtime"
+	"unsafe"
+)
+
+// ConfigKey 表示配置项（对应 ESteamNetworkingConfigValue）
+type ConfigKey int32
+
+const (
+	ConfigInvalid ConfigKey = 0
+
+	// 连接与缓冲区
+	ConfigTimeoutInitial              ConfigKey = 24 // 初始连接超时（毫秒）
+	ConfigTimeoutConnected            ConfigKey = 25 // 已连接状态下的超时（毫秒）
+	ConfigSendBufferSize              ConfigKey = 9  // 发送缓冲区上限（字节）
+	ConfigRecvBufferSize              ConfigKey = 47 // 接收缓冲区上限（字节）
+	ConfigRecvBufferMessages          ConfigKey = 48 // 接收缓冲区消息数上限
+	ConfigRecvMaxMessageSize          ConfigKey = 49 // 单条消息最大字节数
+	ConfigRecvMaxSegmentsPerPacket    ConfigKey = 50 // 单个包最大分段数
+	ConfigConnectionUserData          ConfigKey = 40 // 连接用户数据（int64）
+	ConfigSendRateMin                 ConfigKey = 10 // 最小发送速率（字节/秒）
+	ConfigSendRateMax                 ConfigKey = 11 // 最大发送速率（字节/秒）
+	ConfigNagleTime                   ConfigKey = 12 // Nagle 延迟（微秒）
+	ConfigIPAllowWithoutAuth          ConfigKey = 23 // 允许未认证的 IP 连接
+	ConfigIPLocalHostAllowWithoutAuth ConfigKey = 52 // 允许未认证的本机连接
+	ConfigMTUPacketSize               ConfigKey = 32 // MTU 包大小
+	ConfigMTUDataSize                 ConfigKey = 33 // MTU 数据大小（只读）
+	ConfigUnencrypted                 ConfigKey = 34 // 允许不加密（仅开发用）
+	ConfigSymmetricConnect            ConfigKey = 37 // 对称连接模式
+	ConfigLocalVirtualPort            ConfigKey = 38 // 本地虚拟端口
+	ConfigDualWifiEnable              ConfigKey = 39 // 启用双 Wi-Fi
+	ConfigEnableDiagnosticsUI         ConfigKey = 46 // 启用诊断界面
+	ConfigSendTimeSincePreviousPacket ConfigKey = 59 // 发送与上一个包的时间间隔
+
+	// 模拟网络环境（仅用于调试）
+	ConfigFakePacketLossSend         ConfigKey = 2  // 模拟发送丢包率（百分比）
+	ConfigFakePacketLossRecv         ConfigKey = 3  // 模拟接收丢包率（百分比）
+	ConfigFakePacketLagSend          ConfigKey = 4  // 模拟发送延迟（毫秒）
+	ConfigFakePacketLagRecv          ConfigKey = 5  // 模拟接收延迟（毫秒）
+	ConfigFakePacketJitterSendAvg    ConfigKey = 53 // 模拟发送抖动均值（毫秒）
+	ConfigFakePacketJitterSendMax    ConfigKey = 54 // 模拟发送抖动最大值（毫秒）
+	ConfigFakePacketJitterSendPct    ConfigKey = 55 // 模拟发送抖动比例（百分比）
+	ConfigFakePacketJitterRecvAvg    ConfigKey = 56 // 模拟接收抖动均值（毫秒）
+	ConfigFakePacketJitterRecvMax    ConfigKey = 57 // 模拟接收抖动最大值（毫秒）
+	ConfigFakePacketJitterRecvPct    ConfigKey = 58 // 模拟接收抖动比例（百分比）
+	ConfigFakePacketReorderSend      ConfigKey = 6  // 模拟发送乱序率（百分比）
+	ConfigFakePacketReorderRecv      ConfigKey = 7  // 模拟接收乱序率（百分比）
+	ConfigFakePacketReorderTime      ConfigKey = 8  // 模拟乱序延迟（毫秒）
+	ConfigFakePacketDupSend          ConfigKey = 26 // 模拟发送重复率（百分比）
+	ConfigFakePacketDupRecv          ConfigKey = 27 // 模拟接收重复率（百分比）
+	ConfigFakePacketDupTimeMax       ConfigKey = 28 // 模拟重复包最大延迟（微秒）
+	ConfigPacketTraceMaxBytes        ConfigKey = 41 // 包跟踪最大字节数
+	ConfigFakeRateLimitSendRate      ConfigKey = 42 // 模拟发送限速（字节/秒）
+	ConfigFakeRateLimitSendBurst     ConfigKey = 43 // 模拟发送突发量（字节）
+	ConfigFakeRateLimitRecvRate      ConfigKey = 44 // 模拟接收限速（字节/秒）
+	ConfigFakeRateLimitRecvBurst     ConfigKey = 45 // 模拟接收突发量（字节）
+	ConfigOutOfOrderCorrectionWindow ConfigKey = 51 // 乱序纠正窗口（微秒）
+
+	// 回调函数指针
+	ConfigCallbackConnectionStatusChanged   ConfigKey = 201
+	ConfigCallbackAuthStatusChanged         ConfigKey = 202
+	ConfigCallbackRelayNetworkStatusChanged ConfigKey = 203
+	ConfigCallbackMessagesSessionRequest    ConfigKey = 204
+	ConfigCallbackMessagesSessionFailed     ConfigKey = 205
+	ConfigCallbackCreateConnectionSignaling ConfigKey = 206
+	ConfigCallbackFakeIPResult              ConfigKey = 207
+
+	// P2P 与 ICE
+	ConfigP2PSTUNServerList             ConfigKey = 103
+	ConfigP2PTransportICEEnable         ConfigKey = 104
+	ConfigP2PTransportICEPenalty        ConfigKey = 105
+	ConfigP2PTransportSDRPenalty        ConfigKey = 106
+	ConfigP2PTURNServerList             ConfigKey = 107
+	ConfigP2PTURNUserList               ConfigKey = 108
+	ConfigP2PTURNPassList               ConfigKey = 109
+	ConfigP2PTransportICEImplementation ConfigKey = 110
+
+	// Steam Datagram Relay 客户端
+	ConfigSDRClientConsecutivePingTimeoutsFailInitial ConfigKey = 19
+	ConfigSDRClientConsecutivePingTimeoutsFail        ConfigKey = 20
+	ConfigSDRClientMinPingsBeforePingAccurate         ConfigKey = 21
+	ConfigSDRClientSingleSocket                       ConfigKey = 22
+	ConfigSDRClientForceRelayCluster                  ConfigKey = 29
+	ConfigSDRClientDevTicket                          ConfigKey = 30
+	ConfigSDRClientForceProxyAddr                     ConfigKey = 31
+	ConfigSDRClientFakeClusterPing                    ConfigKey = 36
+	ConfigSDRClientLimitPingProbesToNearestN          ConfigKey = 60
+
+	// 日志级别
+	ConfigLogLevelAckRTT        ConfigKey = 13
+	ConfigLogLevelPacketDecode  ConfigKey = 14
+	ConfigLogLevelMessage       ConfigKey = 15
+	ConfigLogLevelPacketGaps    ConfigKey = 16
+	ConfigLogLevelP2PRendezvous ConfigKey = 17
+	ConfigLogLevelSDRRelayPings ConfigKey = 18
+)
+
+// ConfigDataType 表示配置值的数据类型（对应 ESteamNetworkingConfigDataType）
+type ConfigDataType int32
+
+const (
+	ConfigDataTypeInvalid ConfigDataType = 0
+	ConfigDataTypeInt32   ConfigDataType = 1
+	ConfigDataTypeInt64   ConfigDataType = 2
+	ConfigDataTypeFloat   ConfigDataType = 3
+	ConfigDataTypeString  ConfigDataType = 4
+	ConfigDataTypePtr     ConfigDataType = 5
+)
+
+// String 返回数据类型的字符串表示
+func (t ConfigDataType) String() string {
+	switch t {
+	case ConfigDataTypeInt32:
+		return "Int32"
+	case ConfigDataTypeInt64:
+		return "Int64"
+	case ConfigDataTypeFloat:
+		return "Float"
+	case ConfigDataTypeString:
+		return "String"
+	case ConfigDataTypePtr:
+		return "Ptr"
+	default:
+		return "Unknown"
+	}
+}
+
+// ConfigScope 表示配置的作用域（对应 ESteamNetworkingConfigScope）
+type ConfigScope int32
+
+const (
+	ConfigScopeGlobal           ConfigScope = 1 // 全局
+	ConfigScopeSocketsInterface ConfigScope = 2 // ISteamNetworkingSockets 接口
+	ConfigScopeListenSocket     ConfigScope = 3 // 监听套接字
+	ConfigScopeConnection       ConfigScope = 4 // 连接
+)
+
+// String 返回作用域的字符串表示
+func (s ConfigScope) String() string {
+	switch s {
+	case ConfigScopeGlobal:
+		return "Global"
+	case ConfigScopeSocketsInterface:
+		return "SocketsInterface"
+	case ConfigScopeListenSocket:
+		return "ListenSocket"
+	case ConfigScopeConnection:
+		return "Connection"
+	default:
+		return "Unknown"
+	}
+}
+
+// ConfigValueInfo 描述一个配置项（GetConfigValueInfo 的结果）
+type ConfigValueInfo struct {
+	Key      ConfigKey      // 配置项
+	Name     string         // 原生名称
+	DataType ConfigDataType // 数据类型
+	Scope    ConfigScope    // 可设置的最小作用域
+}
+
+// ConfigSetting 是配置项及其当前值
+type ConfigSetting struct {
+	ConfigValueInfo
+	Value     ConfigValue // 当前值
+	Inherited bool        // 当前值是否继承自上级作用域
+}
+
+// configKeyDef 描述一个已知配置项的名称和数据类型
+type configKeyDef struct {
+	name     string
+	dataType ConfigDataType
+}
+
+// configKeyDefs 是所有已知配置项的表
+var configKeyDefs = map[ConfigKey]configKeyDef{
+	ConfigTimeoutInitial:              {"TimeoutInitial", ConfigDataTypeInt32},
+	ConfigTimeoutConnected:            {"TimeoutConnected", ConfigDataTypeInt32},
+	ConfigSendBufferSize:              {"SendBufferSize", ConfigDataTypeInt32},
+	ConfigRecvBufferSize:              {"RecvBufferSize", ConfigDataTypeInt32},
+	ConfigRecvBufferMessages:          {"RecvBufferMessages", ConfigDataTypeInt32},
+	ConfigRecvMaxMessageSize:          {"RecvMaxMessageSize", ConfigDataTypeInt32},
+	ConfigRecvMaxSegmentsPerPacket:    {"RecvMaxSegmentsPerPacket", ConfigDataTypeInt32},
+	ConfigConnectionUserData:          {"ConnectionUserData", ConfigDataTypeInt64},
+	ConfigSendRateMin:                 {"SendRateMin", ConfigDataTypeInt32},
+	ConfigSendRateMax:                 {"SendRateMax", ConfigDataTypeInt32},
+	ConfigNagleTime:                   {"NagleTime", ConfigDataTypeInt32},
+	ConfigIPAllowWithoutAuth:          {"IP_AllowWithoutAuth", ConfigDataTypeInt32},
+	ConfigIPLocalHostAllowWithoutAuth: {"IPLocalHost_AllowWithoutAuth", ConfigDataTypeInt32},
+	ConfigMTUPacketSize:               {"MTU_PacketSize", ConfigDataTypeInt32},
+	ConfigMTUDataSize:                 {"MTU_DataSize", ConfigDataTypeInt32},
+	ConfigUnencrypted:                 {"Unencrypted", ConfigDataTypeInt32},
+	ConfigSymmetricConnect:            {"SymmetricConnect", ConfigDataTypeInt32},
+	ConfigLocalVirtualPort:            {"LocalVirtualPort", ConfigDataTypeInt32},
+	ConfigDualWifiEnable:              {"DualWifi_Enable", ConfigDataTypeInt32},
+	ConfigEnableDiagnosticsUI:         {"EnableDiagnosticsUI", ConfigDataTypeInt32},
+	ConfigSendTimeSincePreviousPacket: {"SendTimeSincePreviousPacket", ConfigDataTypeInt32},
+
+	ConfigFakePacketLossSend:         {"FakePacketLoss_Send", ConfigDataTypeFloat},
+	ConfigFakePacketLossRecv:         {"FakePacketLoss_Recv", ConfigDataTypeFloat},
+	ConfigFakePacketLagSend:          {"FakePacketLag_Send", ConfigDataTypeInt32},
+	ConfigFakePacketLagRecv:          {"FakePacketLag_Recv", ConfigDataTypeInt32},
+	ConfigFakePacketJitterSendAvg:    {"FakePacketJitter_Send_Avg", ConfigDataTypeInt32},
+	ConfigFakePacketJitterSendMax:    {"FakePacketJitter_Send_Max", ConfigDataTypeInt32},
+	ConfigFakePacketJitterSendPct:    {"FakePacketJitter_Send_Pct", ConfigDataTypeFloat},
+	ConfigFakePacketJitterRecvAvg:    {"FakePacketJitter_Recv_Avg", ConfigDataTypeInt32},
+	ConfigFakePacketJitterRecvMax:    {"FakePacketJitter_Recv_Max", ConfigDataTypeInt32},
+	ConfigFakePacketJitterRecvPct:    {"FakePacketJitter_Recv_Pct", ConfigDataTypeFloat},
+	ConfigFakePacketReorderSend:      {"FakePacketReorder_Send", ConfigDataTypeFloat},
+	ConfigFakePacketReorderRecv:      {"FakePacketReorder_Recv", ConfigDataTypeFloat},
+	ConfigFakePacketReorderTime:      {"FakePacketReorder_Time", ConfigDataTypeInt32},
+	ConfigFakePacketDupSend:          {"FakePacketDup_Send", ConfigDataTypeFloat},
+	ConfigFakePacketDupRecv:          {"FakePacketDup_Recv", ConfigDataTypeFloat},
+	ConfigFakePacketDupTimeMax:       {"FakePacketDup_TimeMax", ConfigDataTypeInt32},
+	ConfigPacketTraceMaxBytes:        {"PacketTraceMaxBytes", ConfigDataTypeInt32},
+	ConfigFakeRateLimitSendRate:      {"FakeRateLimit_Send_Rate", ConfigDataTypeInt32},
+	ConfigFakeRateLimitSendBurst:     {"FakeRateLimit_Send_Burst", ConfigDataTypeInt32},
+	ConfigFakeRateLimitRecvRate:      {"FakeRateLimit_Recv_Rate", ConfigDataTypeInt32},
+	ConfigFakeRateLimitRecvBurst:     {"FakeRateLimit_Recv_Burst", ConfigDataTypeInt32},
+	ConfigOutOfOrderCorrectionWindow: {"OutOfOrderCorrectionWindowMicroseconds", ConfigDataTypeInt32},
+
+	ConfigCallbackConnectionStatusChanged:   {"Callback_ConnectionStatusChanged", ConfigDataTypePtr},
+	ConfigCallbackAuthStatusChanged:         {"Callback_AuthStatusChanged", ConfigDataTypePtr},
+	ConfigCallbackRelayNetworkStatusChanged: {"Callback_RelayNetworkStatusChanged", ConfigDataTypePtr},
+	ConfigCallbackMessagesSessionRequest:    {"Callback_MessagesSessionRequest", ConfigDataTypePtr},
+	ConfigCallbackMessagesSessionFailed:     {"Callback_MessagesSessionFailed", ConfigDataTypePtr},
+	ConfigCallbackCreateConnectionSignaling: {"Callback_CreateConnectionSignaling", ConfigDataTypePtr},
+	ConfigCallbackFakeIPResult:              {"Callback_FakeIPResult", ConfigDataTypePtr},
+
+	ConfigP2PSTUNServerList:             {"P2P_STUN_ServerList", ConfigDataTypeString},
+	ConfigP2PTransportICEEnable:         {"P2P_Transport_ICE_Enable", ConfigDataTypeInt32},
+	ConfigP2PTransportICEPenalty:        {"P2P_Transport_ICE_Penalty", ConfigDataTypeInt32},
+	ConfigP2PTransportSDRPenalty:        {"P2P_Transport_SDR_Penalty", ConfigDataTypeInt32},
+	ConfigP2PTURNServerList:             {"P2P_TURN_ServerList", ConfigDataTypeString},
+	ConfigP2PTURNUserList:               {"P2P_TURN_UserList", ConfigDataTypeString},
+	ConfigP2PTURNPassList:               {"P2P_TURN_PassList", ConfigDataTypeString},
+	ConfigP2PTransportICEImplementation: {"P2P_Transport_ICE_Implementation", ConfigDataTypeInt32},
+
+	ConfigSDRClientConsecutivePingTimeoutsFailInitial: {"SDRClient_ConsecutitivePingTimeoutsFailInitial", ConfigDataTypeInt32},
+	ConfigSDRClientConsecutivePingTimeoutsFail:        {"SDRClient_ConsecutitivePingTimeoutsFail", ConfigDataTypeInt32},
+	ConfigSDRClientMinPingsBeforePingAccurate:         {"SDRClient_MinPingsBeforePingAccurate", ConfigDataTypeInt32},
+	ConfigSDRClientSingleSocket:                       {"SDRClient_SingleSocket", ConfigDataTypeInt32},
+	ConfigSDRClientForceRelayCluster:                  {"SDRClient_ForceRelayCluster", ConfigDataTypeString},
+	ConfigSDRClientDevTicket:                          {"SDRClient_DevTicket", ConfigDataTypeString},
+	ConfigSDRClientForceProxyAddr:                     {"SDRClient_ForceProxyAddr", ConfigDataTypeString},
+	ConfigSDRClientFakeClusterPing:                    {"SDRClient_FakeClusterPing", ConfigDataTypeString},
+	ConfigSDRClientLimitPingProbesToNearestN:          {"SDRClient_LimitPingProbesToNearestN", ConfigDataTypeInt32},
+
+	ConfigLogLevelAckRTT:        {"LogLevel_AckRTT", ConfigDataTypeInt32},
+	ConfigLogLevelPacketDecode:  {"LogLevel_PacketDecode", ConfigDataTypeInt32},
+	ConfigLogLevelMessage:       {"LogLevel_Message", ConfigDataTypeInt32},
+	ConfigLogLevelPacketGaps:    {"LogLevel_PacketGaps", ConfigDataTypeInt32},
+	ConfigLogLevelP2PRendezvous: {"LogLevel_P2PRendezvous", ConfigDataTypeInt32},
+	ConfigLogLevelSDRRelayPings: {"LogLevel_SDRRelayPings", ConfigDataTypeInt32},
+}
+
+// String 返回配置项的名称
+func (k ConfigKey) String() string {
+	if def, ok := configKeyDefs[k]; ok {
+		return def.name
+	}
+	return fmt.Sprintf("ConfigKey(%d)", int32(k))
+}
+
+// DataType 返回配置项期望的数据类型
+// 未知配置项返回 ConfigDataTypeInvalid
+func (k ConfigKey) DataType() ConfigDataType {
+	return configKeyDefs[k].dataType
+}
+
+// IsKnown 检查配置项是否在已知列表中
+func (k ConfigKey) IsKnown() bool {
+	_, ok := configKeyDefs[k]
+	return ok
+}
+
+// ConfigValue 表示一个配置选项（对应 SteamNetworkingConfigValue_t）
+// 只能通过 NewConfigValueXxx 系列函数创建
+type ConfigValue struct {
+	key      ConfigKey
+	dataType ConfigDataType
+	intVal   int64   // Int32 / Int64
+	floatVal float32 // Float
+	strVal   string  // String
+	ptrVal   uintptr // Ptr
+}
+
+// NewConfigValueInt32 创建 int32 类型的配置选项
+func NewConfigValueInt32(key ConfigKey, value int32) ConfigValue {
+	return ConfigValue{key: key, dataType: ConfigDataTypeInt32, intVal: int64(value)}
+}
+
+// NewConfigValueInt64 创建 int64 类型的配置选项
+func NewConfigValueInt64(key ConfigKey, value int64) ConfigValue {
+	return ConfigValue{key: key, dataType: ConfigDataTypeInt64, intVal: value}
+}
+
+// NewConfigValueFloat 创建 float 类型的配置选项
+func NewConfigValueFloat(key ConfigKey, value float32) ConfigValue {
+	return ConfigValue{key: key, dataType: ConfigDataTypeFloat, floatVal: value}
+}
+
+// NewConfigValueString 创建字符串类型的配置选项
+func NewConfigValueString(key ConfigKey, value string) ConfigValue {
+	return ConfigValue{key: key, dataType: ConfigDataTypeString, strVal: value}
+}
+
+// NewConfigValuePtr 创建指针类型的配置选项（通常是回调函数指针）
+func NewConfigValuePtr(key ConfigKey, value uintptr) ConfigValue {
+	return ConfigValue{key: key, dataType: ConfigDataTypePtr, ptrVal: value}
+}
+
+// Key 返回配置项
+func (v ConfigValue) Key() ConfigKey {
+	return v.key
+}
+
+// DataType 返回配置值的数据类型
+func (v ConfigValue) DataType() ConfigDataType {
+	return v.dataType
+}
+
+// Int32 返回 int32 值
+func (v ConfigValue) Int32() int32 {
+	return int32(v.intVal)
+}
+
+// Int64 返回 int64 值
+func (v ConfigValue) Int64() int64 {
+	return v.intVal
+}
+
+// Float 返回 float 值
+func (v ConfigValue) Float() float32 {
+	return v.floatVal
+}
+
+// Str 返回字符串值
+func (v ConfigValue) Str() string {
+	return v.strVal
+}
+
+// Ptr 返回指针值
+func (v ConfigValue) Ptr() uintptr {
+	return v.ptrVal
+}
+
+// String 返回配置选项的字符串表示
+func (v ConfigValue) String() string {
+	switch v.dataType {
+	case ConfigDataTypeInt32, ConfigDataTypeInt64:
+		return fmt.Sprintf("%s=%d", v.key, v.intVal)
+	case ConfigDataTypeFloat:
+		return fmt.Sprintf("%s=%g", v.key, v.floatVal)
+	case ConfigDataTypeString:
+		return fmt.Sprintf("%s=%q", v.key, v.strVal)
+	case ConfigDataTypePtr:
+		return fmt.Sprintf("%s=0x%x", v.key, v.ptrVal)
+	default:
+		return fmt.Sprintf("%s=<invalid>", v.key)
+	}
+}
+
+// Validate 检查配置选项的数据类型是否与配置项匹配
+// 未知配置项（例如更新的 SDK 新增的配置项）只要求数据类型有效，是否接受由原生层判断
+func (v ConfigValue) Validate() error {
+	if v.key == ConfigInvalid {
+		return &Error{Op: "Validate", Message: "invalid config key", Err: ErrInvalidConfigValue}
+	}
+	expected := v.key.DataType()
+	if expected == ConfigDataTypeInvalid {
+		if v.dataType == ConfigDataTypeInvalid {
+			return &Error{Op: "Validate", Message: fmt.Sprintf("%s has no value", v.key), Err: ErrInvalidConfigValue}
+		}
+		return nil
+	}
+	if v.dataType != expected {
+		return &Error{Op: "Validate", Message: fmt.Sprintf("%s expects %s, got %s", v.key, expected, v.dataType), Err: ErrInvalidConfigValue}
+	}
+	return nil
+}
+
+// configValueSize 是 SteamNetworkingConfigValue_t 的大小
+// 结构体布局:
+//
+//	offset 0: ESteamNetworkingConfigValue m_eValue (int32)
+//	offset 4: ESteamNetworkingConfigDataType m_eDataType (int32)
+//	offset 8: union m_val (int32 / int64 / float / const char* / void*)
+const configValueSize = 16
+
+// configValueArray 是编组后的 SteamNetworkingConfigValue_t 数组
+// 在原生调用完成之前必须保持存活
+type configValueArray struct {
+	buf     []byte
+	strings [][]byte // 以 0 结尾的 C 字符串，被 buf 中的指针引用
+}
+
+// marshalConfigValues 将配置选项编组为原生数组
+// options 为空时返回 nil
+func marshalConfigValues(options []ConfigValue) (*configValueArray, error) {
+	if len(options) == 0 {
+		return nil, nil
+	}
+
+	arr := &configValueArray{buf: make([]byte, len(options)*configValueSize)}
+	for i, opt := range options {
+		if err := opt.Validate(); err != nil {
+			return nil, err
+		}
+
+		entry := arr.buf[i*configValueSize : (i+1)*configValueSize]
+		binary.LittleEndian.PutUint32(entry[0:], uint32(opt.key))
+		binary.LittleEndian.PutUint32(entry[4:], uint32(opt.dataType))
+
+		switch opt.dataType {
+		case ConfigDataTypeInt32:
+			binary.LittleEndian.PutUint32(entry[8:], uint32(int32(opt.intVal)))
+		case ConfigDataTypeInt64:
+			binary.LittleEndian.PutUint64(entry[8:], uint64(opt.intVal))
+		case ConfigDataTypeFloat:
+			binary.LittleEndian.PutUint32(entry[8:], math.Float32bits(opt.floatVal))
+		case ConfigDataTypeString:
+			cstr := append([]byte(opt.strVal), 0)
+			arr.strings = append(arr.strings, cstr)
+			binary.LittleEndian.PutUint64(entry[8:], uint64(uintptr(unsafe.Pointer(&cstr[0]))))
+		case ConfigDataTypePtr:
+			binary.LittleEndian.PutUint64(entry[8:], uint64(opt.ptrVal))
+		}
+	}
+
+	return arr, nil
+}
+
+// ptr 返回数组首地址，空数组返回 0
+func (a *configValueArray) ptr() uintptr {
+	if a == nil || len(a.buf) == 0 {
+		return 0
+	}
+	return uintptr(unsafe.Pointer(&a.buf[0]))
+}
+
+// count 返回数组元素个数
+func (a *configValueArray) count() int32 {
+	if a == nil {
+		return 0
+	}
+	return int32(len(a.buf) / configValueSize)
+}
+
+// keepAlive 确保数组及其引用的字符串在原生调用期间不被回收
+func (a *configValueArray) keepAlive() {
+	runtime.KeepAlive(a)
+}
+
+// configValueArg 构造 SetConfigValue 的 pArg 参数
+// 返回的缓冲区在原生调用期间必须保持存活
+func configValueArg(v ConfigValue) ([]byte, uintptr) {
+	var buf []byte
+	switch v.dataType {
+	case ConfigDataTypeInt32:
+		buf = make([]byte, 4)
+		binary.LittleEndian.PutUint32(buf, uint32(int32(v.intVal)))
+	case ConfigDataTypeInt64:
+		buf = make([]byte, 8)
+		binary.LittleEndian.PutUint64(buf, uint64(v.intVal))
+	case ConfigDataTypeFloat:
+		buf = make([]byte, 4)
+		binary.LittleEndian.PutUint32(buf, math.Float32bits(v.floatVal))
+	case ConfigDataTypeString:
+		// 字符串直接以 const char* 传递
+		buf = append([]byte(v.strVal), 0)
+	case ConfigDataTypePtr:
+		// 指针以 void** 传递
+		buf = make([]byte, 8)
+		binary.LittleEndian.PutUint64(buf, uint64(v.ptrVal))
+	default:
+		return nil, 0
+	}
+	return buf, uintptr(unsafe.Pointer(&buf[0]))
+}
+
+// decodeConfigValue 从 GetConfigValue 的结果缓冲区解码配置值
+func decodeConfigValue(key ConfigKey, dataType ConfigDataType, buf []byte) ConfigValue {
+	switch dataType {
+	case ConfigDataTypeInt32:
+		if len(buf) >= 4 {
+			return NewConfigValueInt32(key, int32(binary.LittleEndian.Uint32(buf)))
+		}
+	case ConfigDataTypeInt64:
+		if len(buf) >= 8 {
+			return NewConfigValueInt64(key, int64(binary.LittleEndian.Uint64(buf)))
+		}
+	case ConfigDataTypeFloat:
+		if len(buf) >= 4 {
+			return NewConfigValueFloat(key, math.Float32frombits(binary.LittleEndian.Uint32(buf)))
+		}
+	case ConfigDataTypeString:
+		for i, b := range buf {
+			if b == 0 {
+				return NewConfigValueString(key, string(buf[:i]))
+			}
+		}
+		return NewConfigValueString(key, string(buf))
+	case ConfigDataTypePtr:
+		if len(buf) >= 8 {
+			return NewConfigValuePtr(key, uintptr(binary.LittleEndian.Uint64(buf)))
+		}
+	}
+	return ConfigValue{key: key}
+}
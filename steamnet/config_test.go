@@ -0,0 +1,186 @@
+package steamnet
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+	"unsafe"
+)
+
+func TestConfigKey_String(t *testing.T) {
+	tests := []struct {
+		key      ConfigKey
+		expected string
+	}{
+		{ConfigTimeoutConnected, "TimeoutConnected"},
+		{ConfigSendRateMax, "SendRateMax"},
+		{ConfigCallbackConnectionStatusChanged, "Callback_ConnectionStatusChanged"},
+		{ConfigKey(9999), "ConfigKey(9999)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if got := tt.key.String(); got != tt.expected {
+				t.Errorf("ConfigKey.String() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestConfigValue_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   ConfigValue
+		wantErr bool
+	}{
+		{"Int32", NewConfigValueInt32(ConfigTimeoutConnected, 10000), false},
+		{"Int64", NewConfigValueInt64(ConfigConnectionUserData, 42), false},
+		{"Float", NewConfigValueFloat(ConfigFakePacketLossSend, 5.0), false},
+		{"String", NewConfigValueString(ConfigP2PSTUNServerList, "stun.example.com:3478"), false},
+		{"Ptr", NewConfigValuePtr(ConfigCallbackConnectionStatusChanged, 0x1234), false},
+		{"WrongType", NewConfigValueInt64(ConfigTimeoutConnected, 10000), true},
+		{"FloatForInt", NewConfigValueFloat(ConfigSendRateMin, 1.0), true},
+		{"UnknownKey", NewConfigValueInt32(ConfigKey(9999), 1), false},
+		{"UnknownKeyNoValue", ConfigValue{key: ConfigKey(9999)}, true},
+		{"ZeroValue", ConfigValue{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.value.Validate()
+			if tt.wantErr {
+				if !IsInvalidConfigValue(err) {
+					t.Errorf("Validate() error = %v, want ErrInvalidConfigValue", err)
+				}
+			} else if err != nil {
+				t.Errorf("Validate() error = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func TestMarshalConfigValues(t *testing.T) {
+	options := []ConfigValue{
+		NewConfigValueInt32(ConfigTimeoutConnected, 10000),
+		NewConfigValueInt64(ConfigConnectionUserData, -7),
+		NewConfigValueFloat(ConfigFakePacketLossSend, 2.5),
+		NewConfigValueString(ConfigP2PSTUNServerList, "stun"),
+		NewConfigValuePtr(ConfigCallbackConnectionStatusChanged, 0xdeadbeef),
+	}
+
+	arr, err := marshalConfigValues(options)
+	if err != nil {
+		t.Fatalf("marshalConfigValues() error = %v", err)
+	}
+	if arr.count() != int32(len(options)) {
+		t.Fatalf("count() = %d, want %d", arr.count(), len(options))
+	}
+	if arr.ptr() == 0 {
+		t.Fatal("ptr() = 0, want non-zero")
+	}
+
+	entry := func(i int) []byte {
+		return arr.buf[i*configValueSize : (i+1)*configValueSize]
+	}
+
+	for i, opt := range options {
+		e := entry(i)
+		if got := ConfigKey(binary.LittleEndian.Uint32(e[0:])); got != opt.Key() {
+			t.Errorf("entry %d key = %v, want %v", i, got, opt.Key())
+		}
+		if got := ConfigDataType(binary.LittleEndian.Uint32(e[4:])); got != opt.DataType() {
+			t.Errorf("entry %d data type = %v, want %v", i, got, opt.DataType())
+		}
+	}
+
+	if got := int32(binary.LittleEndian.Uint32(entry(0)[8:])); got != 10000 {
+		t.Errorf("int32 value = %d, want 10000", got)
+	}
+	if got := int64(binary.LittleEndian.Uint64(entry(1)[8:])); got != -7 {
+		t.Errorf("int64 value = %d, want -7", got)
+	}
+	if got := math.Float32frombits(binary.LittleEndian.Uint32(entry(2)[8:])); got != 2.5 {
+		t.Errorf("float value = %f, want 2.5", got)
+	}
+
+	strPtr := uintptr(binary.LittleEndian.Uint64(entry(3)[8:]))
+	if len(arr.strings) != 1 || strPtr != uintptr(unsafe.Pointer(&arr.strings[0][0])) {
+		t.Error("string value does not point to the retained C string")
+	}
+	if got := string(arr.strings[0]); got != "stun\x00" {
+		t.Errorf("C string = %q, want %q", got, "stun\x00")
+	}
+
+	if got := binary.LittleEndian.Uint64(entry(4)[8:]); got != 0xdeadbeef {
+		t.Errorf("ptr value = 0x%x, want 0xdeadbeef", got)
+	}
+}
+
+func TestMarshalConfigValues_Empty(t *testing.T) {
+	arr, err := marshalConfigValues(nil)
+	if err != nil {
+		t.Fatalf("marshalConfigValues(nil) error = %v", err)
+	}
+	if arr.count() != 0 || arr.ptr() != 0 {
+		t.Errorf("empty array = (%d, 0x%x), want (0, 0)", arr.count(), arr.ptr())
+	}
+}
+
+func TestMarshalConfigValues_InvalidType(t *testing.T) {
+	options := []ConfigValue{
+		NewConfigValueInt32(ConfigTimeoutConnected, 10000),
+		NewConfigValueString(ConfigSendRateMax, "fast"),
+	}
+
+	if _, err := marshalConfigValues(options); !IsInvalidConfigValue(err) {
+		t.Errorf("marshalConfigValues() error = %v, want ErrInvalidConfigValue", err)
+	}
+}
+
+func TestConfigScope_String(t *testing.T) {
+	tests := []struct {
+		scope    ConfigScope
+		expected string
+	}{
+		{ConfigScopeGlobal, "Global"},
+		{ConfigScopeSocketsInterface, "SocketsInterface"},
+		{ConfigScopeListenSocket, "ListenSocket"},
+		{ConfigScopeConnection, "Connection"},
+		{ConfigScope(999), "Unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if got := tt.scope.String(); got != tt.expected {
+				t.Errorf("ConfigScope.String() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestConfigValueArg_RoundTrip(t *testing.T) {
+	values := []ConfigValue{
+		NewConfigValueInt32(ConfigSendRateMin, 128000),
+		NewConfigValueInt64(ConfigConnectionUserData, 1<<40),
+		NewConfigValueFloat(ConfigFakePacketLossRecv, 1.5),
+		NewConfigValueString(ConfigP2PTURNServerList, "turn.example.com"),
+		NewConfigValuePtr(ConfigCallbackConnectionStatusChanged, 0xcafe),
+	}
+
+	for _, v := range values {
+		t.Run(v.Key().String(), func(t *testing.T) {
+			buf, arg := configValueArg(v)
+			if arg == 0 {
+				t.Fatal("configValueArg() returned nil pointer")
+			}
+			got := decodeConfigValue(v.Key(), v.DataType(), buf)
+			if got != v {
+				t.Errorf("decodeConfigValue() = %v, want %v", got, v)
+			}
+		})
+	}
+
+	if _, arg := configValueArg(ConfigValue{}); arg != 0 {
+		t.Error("configValueArg() of zero value should return nil pointer")
+	}
+}
@@ -0,0 +1,169 @@
+package steamnet
+
+import "fmt"
+
+// ConnectionEndReason 表示连接结束的原因（对应 ESteamNetConnectionEnd）
+// 取值按范围分类：
+//
+//	1000-1999: 应用正常关闭（App），应用自定义子码
+//	2000-2999: 应用异常关闭（AppException），应用自定义子码
+//	3000-3999: 本地问题（Local）
+//	4000-4999: 远程问题（Remote）
+//	5000-5999: 其他问题（Misc）
+type ConnectionEndReason int32
+
+const (
+	EndReasonInvalid ConnectionEndReason = 0 // 未结束或未设置
+
+	// 应用正常关闭，通过 AppEndReason 定义自己的子码
+	EndReasonAppMin     ConnectionEndReason = 1000
+	EndReasonAppGeneric ConnectionEndReason = EndReasonAppMin
+	EndReasonAppMax     ConnectionEndReason = 1999
+
+	// 应用异常关闭，通过 AppExceptionEndReason 定义自己的子码
+	EndReasonAppExceptionMin     ConnectionEndReason = 2000
+	EndReasonAppExceptionGeneric ConnectionEndReason = EndReasonAppExceptionMin
+	EndReasonAppExceptionMax     ConnectionEndReason = 2999
+
+	// 本地问题
+	EndReasonLocalMin                      ConnectionEndReason = 3000
+	EndReasonLocalOfflineMode              ConnectionEndReason = 3001 // 处于离线模式
+	EndReasonLocalManyRelayConnectivity    ConnectionEndReason = 3002 // 无法连接到多数中继
+	EndReasonLocalHostedServerPrimaryRelay ConnectionEndReason = 3003 // 托管服务器无法连接到主中继
+	EndReasonLocalNetworkConfig            ConnectionEndReason = 3004 // 无法获取网络配置
+	EndReasonLocalRights                   ConnectionEndReason = 3005 // 没有权限
+	EndReasonLocalP2PICENoPublicAddresses  ConnectionEndReason = 3006 // 本地没有可用的公网地址（ICE）
+	EndReasonLocalMax                      ConnectionEndReason = 3999
+
+	// 远程问题
+	EndReasonRemoteMin                     ConnectionEndReason = 4000
+	EndReasonRemoteTimeout                 ConnectionEndReason = 4001 // 远程主机超时
+	EndReasonRemoteBadCrypt                ConnectionEndReason = 4002 // 加密握手失败
+	EndReasonRemoteBadCert                 ConnectionEndReason = 4003 // 证书无效
+	EndReasonRemoteBadProtocolVersion      ConnectionEndReason = 4006 // 协议版本不兼容
+	EndReasonRemoteP2PICENoPublicAddresses ConnectionEndReason = 4007 // 远程没有可用的公网地址（ICE）
+	EndReasonRemoteMax                     ConnectionEndReason = 4999
+
+	// 其他问题
+	EndReasonMiscMin                     ConnectionEndReason = 5000
+	EndReasonMiscGeneric                 ConnectionEndReason = 5001 // 其他失败
+	EndReasonMiscInternalError           ConnectionEndReason = 5002 // 内部错误
+	EndReasonMiscTimeout                 ConnectionEndReason = 5003 // 超时（原因不明确）
+	EndReasonMiscSteamConnectivity       ConnectionEndReason = 5005 // 与 Steam 的连接出现问题
+	EndReasonMiscNoRelaySessionsToClient ConnectionEndReason = 5006 // 中继上没有到客户端的会话
+	EndReasonMiscP2PRendezvous           ConnectionEndReason = 5008 // P2P 会合失败
+	EndReasonMiscP2PNATFirewall          ConnectionEndReason = 5009 // NAT 穿透失败
+	EndReasonMiscPeerSentNoConnection    ConnectionEndReason = 5010 // 对方不认识该连接
+	EndReasonMiscMax                     ConnectionEndReason = 5999
+)
+
+// endReasonNames 是已知结束原因的名称
+var endReasonNames = map[ConnectionEndReason]string{
+	EndReasonInvalid:                       "Invalid",
+	EndReasonAppGeneric:                    "AppGeneric",
+	EndReasonAppExceptionGeneric:           "AppExceptionGeneric",
+	EndReasonLocalOfflineMode:              "LocalOfflineMode",
+	EndReasonLocalManyRelayConnectivity:    "LocalManyRelayConnectivity",
+	EndReasonLocalHostedServerPrimaryRelay: "LocalHostedServerPrimaryRelay",
+	EndReasonLocalNetworkConfig:            "LocalNetworkConfig",
+	EndReasonLocalRights:                   "LocalRights",
+	EndReasonLocalP2PICENoPublicAddresses:  "LocalP2PICENoPublicAddresses",
+	EndReasonRemoteTimeout:                 "RemoteTimeout",
+	EndReasonRemoteBadCrypt:                "RemoteBadCrypt",
+	EndReasonRemoteBadCert:                 "RemoteBadCert",
+	EndReasonRemoteBadProtocolVersion:      "RemoteBadProtocolVersion",
+	EndReasonRemoteP2PICENoPublicAddresses: "RemoteP2PICENoPublicAddresses",
+	EndReasonMiscGeneric:                   "MiscGeneric",
+	EndReasonMiscInternalError:             "MiscInternalError",
+	EndReasonMiscTimeout:                   "MiscTimeout",
+	EndReasonMiscSteamConnectivity:         "MiscSteamConnectivity",
+	EndReasonMiscNoRelaySessionsToClient:   "MiscNoRelaySessionsToClient",
+	EndReasonMiscP2PRendezvous:             "MiscP2PRendezvous",
+	EndReasonMiscP2PNATFirewall:            "MiscP2PNATFirewall",
+	EndReasonMiscPeerSentNoConnection:      "MiscPeerSentNoConnection",
+}
+
+// AppEndReason 返回应用正常关闭的结束原因，code 为应用自定义的子码（0-999）
+// 超出范围时返回 EndReasonInvalid
+//
+//	const ReasonKicked = steamnet.AppEndReason(1)
+func AppEndReason(code int) ConnectionEndReason {
+	if code < 0 || code > int(EndReasonAppMax-EndReasonAppMin) {
+		return EndReasonInvalid
+	}
+	return EndReasonAppMin + ConnectionEndReason(code)
+}
+
+// AppExceptionEndReason 返回应用异常关闭的结束原因，code 为应用自定义的子码（0-999）
+// 超出范围时返回 EndReasonInvalid
+func AppExceptionEndReason(code int) ConnectionEndReason {
+	if code < 0 || code > int(EndReasonAppExceptionMax-EndReasonAppExceptionMin) {
+		return EndReasonInvalid
+	}
+	return EndReasonAppExceptionMin + ConnectionEndReason(code)
+}
+
+// IsAppReason 检查是否为应用正常关闭（App 范围）
+func (r ConnectionEndReason) IsAppReason() bool {
+	return r >= EndReasonAppMin && r <= EndReasonAppMax
+}
+
+// IsAppException 检查是否为应用异常关闭（AppException 范围）
+func (r ConnectionEndReason) IsAppException() bool {
+	return r >= EndReasonAppExceptionMin && r <= EndReasonAppExceptionMax
+}
+
+// IsLocalProblem 检查是否为本地问题（Local 范围）
+func (r ConnectionEndReason) IsLocalProblem() bool {
+	return r >= EndReasonLocalMin && r <= EndReasonLocalMax
+}
+
+// IsRemoteProblem 检查是否为远程问题（Remote 范围）
+func (r ConnectionEndReason) IsRemoteProblem() bool {
+	return r >= EndReasonRemoteMin && r <= EndReasonRemoteMax
+}
+
+// IsRemoteTimeout 检查是否为远程主机超时
+func (r ConnectionEndReason) IsRemoteTimeout() bool {
+	return r == EndReasonRemoteTimeout
+}
+
+// IsMisc 检查是否为其他问题（Misc 范围）
+func (r ConnectionEndReason) IsMisc() bool {
+	return r >= EndReasonMiscMin && r <= EndReasonMiscMax
+}
+
+// AppCode 返回 App 或 AppException 范围内应用自定义的子码
+// 不是应用定义的原因时返回 false
+func (r ConnectionEndReason) AppCode() (int, bool) {
+	switch {
+	case r.IsAppReason():
+		return int(r - EndReasonAppMin), true
+	case r.IsAppException():
+		return int(r - EndReasonAppExceptionMin), true
+	default:
+		return 0, false
+	}
+}
+
+// String 返回结束原因的名称
+// 应用自定义子码显示为 App(n) / AppException(n)
+func (r ConnectionEndReason) String() string {
+	if name, ok := endReasonNames[r]; ok {
+		return name
+	}
+	switch {
+	case r.IsAppReason():
+		return fmt.Sprintf("App(%d)", int32(r-EndReasonAppMin))
+	case r.IsAppException():
+		return fmt.Sprintf("AppException(%d)", int32(r-EndReasonAppExceptionMin))
+	case r.IsLocalProblem():
+		return fmt.Sprintf("Local(%d)", int32(r))
+	case r.IsRemoteProblem():
+		return fmt.Sprintf("Remote(%d)", int32(r))
+	case r.IsMisc():
+		return fmt.Sprintf("Misc(%d)", int32(r))
+	default:
+		return fmt.Sprintf("ConnectionEndReason(%d)", int32(r))
+	}
+}
@@ -0,0 +1,92 @@
+package steamnet
+
+import "testing"
+
+func TestConnectionEndReason_Categories(t *testing.T) {
+	tests := []struct {
+		reason       ConnectionEndReason
+		app          bool
+		appException bool
+		local        bool
+		remote       bool
+		misc         bool
+	}{
+		{EndReasonInvalid, false, false, false, false, false},
+		{EndReasonAppGeneric, true, false, false, false, false},
+		{AppEndReason(42), true, false, false, false, false},
+		{AppExceptionEndReason(3), false, true, false, false, false},
+		{EndReasonLocalOfflineMode, false, false, true, false, false},
+		{EndReasonRemoteTimeout, false, false, false, true, false},
+		{EndReasonMiscTimeout, false, false, false, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.reason.String(), func(t *testing.T) {
+			if got := tt.reason.IsAppReason(); got != tt.app {
+				t.Errorf("IsAppReason() = %v, want %v", got, tt.app)
+			}
+			if got := tt.reason.IsAppException(); got != tt.appException {
+				t.Errorf("IsAppException() = %v, want %v", got, tt.appException)
+			}
+			if got := tt.reason.IsLocalProblem(); got != tt.local {
+				t.Errorf("IsLocalProblem() = %v, want %v", got, tt.local)
+			}
+			if got := tt.reason.IsRemoteProblem(); got != tt.remote {
+				t.Errorf("IsRemoteProblem() = %v, want %v", got, tt.remote)
+			}
+			if got := tt.reason.IsMisc(); got != tt.misc {
+				t.Errorf("IsMisc() = %v, want %v", got, tt.misc)
+			}
+		})
+	}
+
+	if !EndReasonRemoteTimeout.IsRemoteTimeout() || EndReasonMiscTimeout.IsRemoteTimeout() {
+		t.Error("IsRemoteTimeout() should only match EndReasonRemoteTimeout")
+	}
+}
+
+func TestConnectionEndReason_AppCode(t *testing.T) {
+	const reasonKicked = 1
+	const reasonBanned = 2
+
+	kicked := AppEndReason(reasonKicked)
+	if kicked != 1001 {
+		t.Errorf("AppEndReason(1) = %d, want 1001", kicked)
+	}
+	if code, ok := kicked.AppCode(); !ok || code != reasonKicked {
+		t.Errorf("AppCode() = (%d, %v), want (%d, true)", code, ok, reasonKicked)
+	}
+	if code, ok := AppExceptionEndReason(reasonBanned).AppCode(); !ok || code != reasonBanned {
+		t.Errorf("AppException AppCode() = (%d, %v), want (%d, true)", code, ok, reasonBanned)
+	}
+	if _, ok := EndReasonRemoteTimeout.AppCode(); ok {
+		t.Error("RemoteTimeout AppCode() ok = true, want false")
+	}
+
+	if AppEndReason(-1) != EndReasonInvalid || AppEndReason(1000) != EndReasonInvalid {
+		t.Error("AppEndReason() out of range should return EndReasonInvalid")
+	}
+	if AppEndReason(999) != EndReasonAppMax || AppExceptionEndReason(999) != EndReasonAppExceptionMax {
+		t.Error("AppEndReason(999) should return the range maximum")
+	}
+}
+
+func TestConnectionEndReason_String(t *testing.T) {
+	tests := []struct {
+		reason ConnectionEndReason
+		want   string
+	}{
+		{EndReasonInvalid, "Invalid"},
+		{EndReasonAppGeneric, "AppGeneric"},
+		{AppEndReason(7), "App(7)"},
+		{AppExceptionEndReason(7), "AppException(7)"},
+		{EndReasonRemoteTimeout, "RemoteTimeout"},
+		{EndReasonMiscP2PNATFirewall, "MiscP2PNATFirewall"},
+		{ConnectionEndReason(3999), "Local(3999)"},
+		{ConnectionEndReason(42), "ConnectionEndReason(42)"},
+	}
+	for _, tt := range tests {
+		if got := tt.reason.String(); got != tt.want {
+			t.Errorf("String(%d) = %q, want %q", int32(tt.reason), got, tt.want)
+		}
+	}
+}
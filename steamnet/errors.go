@@ -3,17 +3,126 @@ package steamnet
 import (
 	"errors"
 	"fmt"
+	"net"
+
+	"github.com/guowei-gong/steamkit-go"
 )
 
 // Error 表示 Steam 网络错误
+//
+// 预定义错误（ErrNotConnected 等）只设置 Code 和 Message。
+// 操作失败时返回的错误类似 net.OpError：Op 为失败的操作，Connection、ListenSocket 和 Identity
+// 描述相关的端点，Result 和 EndReason 记录原生原因，Err 为对应的预定义错误，Message 为可选的补充说明。
+// 可以用 errors.Is 匹配预定义错误和 steamkit.EResult：
+//
+//	if errors.Is(err, steamnet.ErrNotConnected) { ... }
+//	if errors.Is(err, steamkit.EResultLimitExceeded) { ... }
+//
+// Error 实现了 net.Error，可以直接用于为 net 包编写的重试逻辑
 type Error struct {
 	Code    int    // Steam 错误码
-	Message string // 错误消息
+	Message string // 错误消息（操作错误中为补充说明）
+
+	Op           string              // 失败的操作，如 "SendMessageToConnection"
+	Connection   Connection          // 相关连接（没有时为 InvalidConnection）
+	ListenSocket ListenSocket        // 相关监听套接字（没有时为 InvalidListenSocket）
+	Identity     Identity            // 远程身份（未知时无效）
+	Result       steamkit.EResult    // 原生返回的 EResult（没有时为 EResultNone）
+	EndReason    ConnectionEndReason // 连接结束原因（没有时为 EndReasonInvalid）
+	Err          error               // 底层错误，通常是预定义错误
 }
 
+var _ net.Error = (*Error)(nil)
+
 // Error 实现 error 接口
 func (e *Error) Error() string {
-	return fmt.Sprintf("steamnet: %s (code: %d)", e.Message, e.Code)
+	if e.Op == "" {
+		return fmt.Sprintf("steamnet: %s (code: %d)", e.Message, e.Code)
+	}
+
+	s := "steamnet: " + e.Op
+	if e.Connection != InvalidConnection {
+		s += fmt.Sprintf(" connection %d", e.Connection)
+	}
+	if e.ListenSocket != InvalidListenSocket {
+		s += fmt.Sprintf(" listen socket %d", e.ListenSocket)
+	}
+	if e.Identity.IsValid() {
+		s += " " + e.Identity.String()
+	}
+
+	var causes []string
+	if e.Err != nil {
+		// 预定义错误只取消息，避免重复的 "steamnet:" 前缀
+		cause := e.Err.Error()
+		if sentinel, ok := e.Err.(*Error); ok && sentinel.Op == "" {
+			cause = sentinel.Message
+		}
+		if e.Message != "" {
+			cause += ": " + e.Message
+		}
+		causes = append(causes, cause)
+	} else if e.Message != "" {
+		causes = append(causes, e.Message)
+	}
+	if e.Result != steamkit.EResultNone {
+		causes = append(causes, fmt.Sprintf("EResult %s (%d)", e.Result.String(), int32(e.Result)))
+	}
+	if e.EndReason != EndReasonInvalid {
+		causes = append(causes, fmt.Sprintf("end reason %s (%d)", e.EndReason.String(), int32(e.EndReason)))
+	}
+	for i, c := range causes {
+		if i == 0 {
+			s += ": " + c
+		} else {
+			s += ", " + c
+		}
+	}
+	return s
+}
+
+// Unwrap 返回底层错误和原生 EResult，使 errors.Is 能同时匹配两者
+func (e *Error) Unwrap() []error {
+	var errs []error
+	if e.Err != nil {
+		errs = append(errs, e.Err)
+	}
+	if e.Result != steamkit.EResultNone && !e.Result.IsOK() {
+		errs = append(errs, e.Result)
+	}
+	return errs
+}
+
+// Is 使 errors.Is 按错误码匹配预定义错误（包括 NewError 创建的同码错误）
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && e.Op == "" && t.Op == "" && t.Code != 0 && t.Code == e.Code
+}
+
+// Timeout 报告错误是否由超时引起
+func (e *Error) Timeout() bool {
+	if e.Result == steamkit.EResultTimeout ||
+		e.EndReason == EndReasonRemoteTimeout || e.EndReason == EndReasonMiscTimeout {
+		return true
+	}
+	var t interface{ Timeout() bool }
+	return e.Err != nil && errors.As(e.Err, &t) && t.Timeout()
+}
+
+// Temporary 报告错误是否是暂时的，稍后重试可能成功
+// 超时、发送缓冲区已满（EResultLimitExceeded）和服务繁忙属于暂时错误
+func (e *Error) Temporary() bool {
+	if e.Timeout() {
+		return true
+	}
+	switch e.Result {
+	case steamkit.EResultLimitExceeded, steamkit.EResultRateLimitExceeded,
+		steamkit.EResultBusy, steamkit.EResultServiceUnavailable,
+		steamkit.EResultPending, steamkit.EResultTryAnotherCM:
+		return true
+	}
+	var t interface{ Temporary() bool }
+	return e.Err != nil && errors.As(e.Err, &t) && t.Temporary()
 }
 
 // 预定义错误码
@@ -28,6 +137,9 @@ const (
 	ErrCodeReceiveFailed      = 8
 	ErrCodeInvalidPollGroup   = 9
 	ErrCodeInvalidMessage     = 10
+	ErrCodeInvalidConfigValue = 11
+	ErrCodeUnsupported        = 12
+	ErrCodeNotInitialized     = 13
 )
 
 // 预定义错误
@@ -42,66 +154,104 @@ var (
 	ErrReceiveFailed      = &Error{Code: ErrCodeReceiveFailed, Message: "receive failed"}
 	ErrInvalidPollGroup   = &Error{Code: ErrCodeInvalidPollGroup, Message: "invalid poll group"}
 	ErrInvalidMessage     = &Error{Code: ErrCodeInvalidMessage, Message: "invalid message"}
+	ErrInvalidConfigValue = &Error{Code: ErrCodeInvalidConfigValue, Message: "invalid config value"}
+	ErrUnsupported        = &Error{Code: ErrCodeUnsupported, Message: "not supported by the loaded Steam library"}
+	ErrNotInitialized     = &Error{Code: ErrCodeNotInitialized, Message: "Steam API is not initialized"}
 )
 
 // IsInvalidConnection 检查是否为无效连接错误
 func IsInvalidConnection(err error) bool {
-	var e *Error
-	return errors.As(err, &e) && e.Code == ErrCodeInvalidConnection
+	return errors.Is(err, ErrInvalidConnection)
 }
 
 // IsInvalidSocket 检查是否为无效套接字错误
 func IsInvalidSocket(err error) bool {
-	var e *Error
-	return errors.As(err, &e) && e.Code == ErrCodeInvalidSocket
+	return errors.Is(err, ErrInvalidSocket)
 }
 
 // IsConnectionFailed 检查是否为连接失败错误
 func IsConnectionFailed(err error) bool {
-	var e *Error
-	return errors.As(err, &e) && e.Code == ErrCodeConnectionFailed
+	return errors.Is(err, ErrConnectionFailed)
 }
 
 // IsNotConnected 检查是否为未连接错误
 func IsNotConnected(err error) bool {
-	var e *Error
-	return errors.As(err, &e) && e.Code == ErrCodeNotConnected
+	return errors.Is(err, ErrNotConnected)
 }
 
 // IsInvalidIdentity 检查是否为无效身份错误
 func IsInvalidIdentity(err error) bool {
-	var e *Error
-	return errors.As(err, &e) && e.Code == ErrCodeInvalidIdentity
+	return errors.Is(err, ErrInvalidIdentity)
 }
 
 // IsAuthFailed 检查是否为认证失败错误
 func IsAuthFailed(err error) bool {
-	var e *Error
-	return errors.As(err, &e) && e.Code == ErrCodeAuthFailed
+	return errors.Is(err, ErrAuthFailed)
 }
 
 // IsSendFailed 检查是否为发送失败错误
 func IsSendFailed(err error) bool {
-	var e *Error
-	return errors.As(err, &e) && e.Code == ErrCodeSendFailed
+	return errors.Is(err, ErrSendFailed)
 }
 
 // IsReceiveFailed 检查是否为接收失败错误
 func IsReceiveFailed(err error) bool {
-	var e *Error
-	return errors.As(err, &e) && e.Code == ErrCodeReceiveFailed
+	return errors.Is(err, ErrReceiveFailed)
 }
 
 // IsInvalidPollGroup 检查是否为无效轮询组错误
 func IsInvalidPollGroup(err error) bool {
-	var e *Error
-	return errors.As(err, &e) && e.Code == ErrCodeInvalidPollGroup
+	return errors.Is(err, ErrInvalidPollGroup)
 }
 
 // IsInvalidMessage 检查是否为无效消息错误
 func IsInvalidMessage(err error) bool {
+	return errors.Is(err, ErrInvalidMessage)
+}
+
+// IsInvalidConfigValue 检查是否为无效配置值错误
+func IsInvalidConfigValue(err error) bool {
+	return errors.Is(err, ErrInvalidConfigValue)
+}
+
+// IsUnsupported 检查是否为加载的 Steam 库不支持该函数的错误
+func IsUnsupported(err error) bool {
+	return errors.Is(err, ErrUnsupported)
+}
+
+// IsNotInitialized 检查是否为 Steam API 未初始化（或接口句柄已在 Shutdown 后失效）的错误
+func IsNotInitialized(err error) bool {
+	return errors.Is(err, ErrNotInitialized)
+}
+
+// resultError 在 result 不是 EResultOK 时返回描述 op 失败的 *Error
+// EResultNoConnection 和 EResultInvalidParam 分别映射为 ErrNotConnected 和 ErrInvalidConnection，
+// 其他结果使用 fallback（可以为 nil）
+func resultError(op string, conn Connection, result steamkit.EResult, fallback error) error {
+	if result.IsOK() {
+		return nil
+	}
+	cause := fallback
+	switch result {
+	case steamkit.EResultNoConnection:
+		cause = ErrNotConnected
+	case steamkit.EResultInvalidParam:
+		cause = ErrInvalidConnection
+	}
+	return &Error{Op: op, Connection: conn, Result: result, Err: cause}
+}
+
+// withOp 返回 op 失败的 *Error
+// err 是内部校验返回的操作错误时复制其说明和原因并替换 Op，避免嵌套；其他错误作为原因包装。
+// 调用方可以继续补充 Connection、Identity 等端点信息
+func withOp(op string, err error) *Error {
 	var e *Error
-	return errors.As(err, &e) && e.Code == ErrCodeInvalidMessage
+	if errors.As(err, &e) && e.Op != "" {
+		c := *e
+		c.Op = op
+		return &c
+	}
+	return &Error{Op: op, Err: err}
 }
 
 // NewError 创建新的错误
@@ -113,6 +263,9 @@ func NewError(code int, message string) *Error {
 }
 
 // WrapError 包装错误
+//
+// Deprecated: 返回的不是 *Error，errors.As 无法取得 Op 等字段。
+// 新代码应返回 &Error{Op: ..., Message: ..., Err: 预定义错误}。
 func WrapError(err error, message string) error {
 	if err == nil {
 		return nil
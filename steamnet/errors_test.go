@@ -2,7 +2,11 @@ package steamnet
 
 import (
 	"errors"
+	"fmt"
+	"net"
 	"testing"
+
+	"github.com/guowei-gong/steamkit-go"
 )
 
 func TestError_Error(t *testing.T) {
@@ -33,6 +37,7 @@ func TestPredefinedErrors(t *testing.T) {
 		{"ReceiveFailed", ErrReceiveFailed, ErrCodeReceiveFailed},
 		{"InvalidPollGroup", ErrInvalidPollGroup, ErrCodeInvalidPollGroup},
 		{"InvalidMessage", ErrInvalidMessage, ErrCodeInvalidMessage},
+		{"InvalidConfigValue", ErrInvalidConfigValue, ErrCodeInvalidConfigValue},
 	}
 
 	for _, tt := range tests {
@@ -109,3 +114,182 @@ func TestWrapError(t *testing.T) {
 		t.Error("WrapError(nil) should return nil")
 	}
 }
+
+func TestOpError_Paths(t *testing.T) {
+	u := &steamNetworkingUtils{handle: 1}
+	_, utilsErr := u.GetConfigValue(ConfigTimeoutInitial, ConfigScopeGlobal, 0)
+	_, parseErr := ParseIdentity("ip:[::1")
+	_, ipErr := ParseIPAddr("1.2.3.4:99999")
+	s := &steamNetworkingSockets{handle: 1, utils: 1}
+	sendErr := s.SendMessages([]OutgoingMessage{{Connection: Connection(3), Data: []byte("x")}})[0].Err
+
+	tests := []struct {
+		name     string
+		err      error
+		op       string
+		sentinel *Error
+	}{
+		{"GetConfigValue", utilsErr, "GetConfigValue", ErrNotInitialized},
+		{"ParseIdentity", parseErr, "ParseIdentity", ErrInvalidIdentity},
+		{"ParseIPAddr", ipErr, "ParseIPAddr", ErrInvalidIdentity},
+		{"checkKey", checkKey("SetConfigValue", ConfigTimeoutConnected, ConfigDataTypeString), "SetConfigValue", ErrInvalidConfigValue},
+		{"SendMessages", sendErr, "SendMessages", ErrNotInitialized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var e *Error
+			if !errors.As(tt.err, &e) || e.Op != tt.op {
+				t.Fatalf("errors.As(%v) = %+v, want Op %q", tt.err, e, tt.op)
+			}
+			if !errors.Is(tt.err, tt.sentinel) {
+				t.Errorf("errors.Is(%v, %v) = false", tt.err, tt.sentinel)
+			}
+		})
+	}
+}
+
+func TestOpError_Message(t *testing.T) {
+	err := &Error{Op: "ParseIPAddr", Message: `invalid port "x"`, Err: ErrInvalidIdentity}
+	want := `steamnet: ParseIPAddr: invalid identity: invalid port "x"`
+	if got := err.Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestRequireSockets_Unsupported(t *testing.T) {
+	// 未加载库时所有函数都不可用
+	err := requireSockets("CreatePollGroup")
+	if !IsUnsupported(err) {
+		t.Errorf("requireSockets() error = %v, want ErrUnsupported", err)
+	}
+
+}
+
+func TestNotInitialized(t *testing.T) {
+	if _, err := GetSockets(); !IsNotInitialized(err) {
+		t.Errorf("GetSockets() error = %v, want ErrNotInitialized", err)
+	}
+	if _, err := GetUtils(); !IsNotInitialized(err) {
+		t.Errorf("GetUtils() error = %v, want ErrNotInitialized", err)
+	}
+
+	// 未初始化或 Shutdown 后的句柄不会调用原生函数
+	s := &steamNetworkingSockets{handle: 1, utils: 1}
+	if _, err := s.CreatePollGroup(); !IsNotInitialized(err) {
+		t.Errorf("CreatePollGroup() error = %v, want ErrNotInitialized", err)
+	}
+	if _, err := s.ConnectP2P(NewIdentityFromSteamID(76561197960287930), 0, nil); !IsNotInitialized(err) {
+		t.Errorf("ConnectP2P() error = %v, want ErrNotInitialized", err)
+	}
+	if got, err := s.GetConnectionUserData(Connection(1)); got != -1 || !IsNotInitialized(err) {
+		t.Errorf("GetConnectionUserData() = (%d, %v), want (-1, ErrNotInitialized)", got, err)
+	}
+	if _, err := s.GetPollGroupConnections(PollGroup(1)); !IsNotInitialized(err) {
+		t.Errorf("GetPollGroupConnections() error = %v, want ErrNotInitialized", err)
+	}
+	results := s.SendMessages([]OutgoingMessage{{Connection: Connection(1), Data: []byte("x")}})
+	if !IsNotInitialized(results[0].Err) {
+		t.Errorf("SendMessages() error = %v, want ErrNotInitialized", results[0].Err)
+	}
+	s.RunCallbacks()
+
+	u := &steamNetworkingUtils{handle: 1}
+	if err := u.SetGlobalConfigValueInt32(ConfigTimeoutInitial, 1000); !IsNotInitialized(err) {
+		t.Errorf("SetGlobalConfigValueInt32() error = %v, want ErrNotInitialized", err)
+	}
+	if got := u.IterateGenericEditableConfigValues(ConfigInvalid, false); got != ConfigInvalid {
+		t.Errorf("IterateGenericEditableConfigValues() = %v, want ConfigInvalid", got)
+	}
+	if got := u.GetIPv4FakeIPType(0xa9fe0001); got != FakeIPTypeInvalid {
+		t.Errorf("GetIPv4FakeIPType() = %v, want FakeIPTypeInvalid", got)
+	}
+}
+
+func TestOpError(t *testing.T) {
+	err := resultError("SendMessageToConnection", Connection(7), steamkit.EResultNoConnection, ErrSendFailed)
+
+	want := "steamnet: SendMessageToConnection connection 7: not connected, EResult NoConnection (3)"
+	if got := err.Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	if !errors.Is(err, ErrNotConnected) || !IsNotConnected(err) {
+		t.Error("errors.Is(err, ErrNotConnected) = false")
+	}
+	if !errors.Is(err, steamkit.EResultNoConnection) {
+		t.Error("errors.Is(err, EResultNoConnection) = false")
+	}
+	if errors.Is(err, ErrSendFailed) || errors.Is(err, steamkit.EResultLimitExceeded) {
+		t.Error("error matched an unrelated sentinel")
+	}
+
+	var e *Error
+	if !errors.As(fmt.Errorf("context: %w", err), &e) || e.Op != "SendMessageToConnection" || e.Connection != 7 {
+		t.Errorf("errors.As() = %+v", e)
+	}
+
+	if resultError("SendMessageToConnection", Connection(7), steamkit.EResultOK, ErrSendFailed) != nil {
+		t.Error("resultError(EResultOK) != nil")
+	}
+
+	// 未映射的结果使用 fallback
+	full := resultError("SendMessageToConnection", Connection(7), steamkit.EResultLimitExceeded, ErrSendFailed)
+	if !IsSendFailed(full) || !errors.Is(full, steamkit.EResultLimitExceeded) {
+		t.Errorf("LimitExceeded error = %v, want ErrSendFailed and EResultLimitExceeded", full)
+	}
+}
+
+func TestOpError_NetError(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       *Error
+		timeout   bool
+		temporary bool
+	}{
+		{"Sentinel", ErrNotConnected, false, false},
+		{"InvalidConnection", &Error{Op: "CloseConnection", Connection: 1, Err: ErrInvalidConnection}, false, false},
+		{"ResultTimeout", &Error{Op: "AcceptConnection", Result: steamkit.EResultTimeout}, true, true},
+		{"RemoteTimeout", &Error{Op: "connection closed", EndReason: EndReasonRemoteTimeout, Err: ErrNotConnected}, true, true},
+		{"LimitExceeded", &Error{Op: "SendMessageToConnection", Result: steamkit.EResultLimitExceeded, Err: ErrSendFailed}, false, true},
+		{"AppClosed", &Error{Op: "connection closed", EndReason: EndReasonAppGeneric, Err: ErrNotConnected}, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var netErr net.Error
+			if !errors.As(error(tt.err), &netErr) {
+				t.Fatal("*Error does not implement net.Error")
+			}
+			if got := netErr.Timeout(); got != tt.timeout {
+				t.Errorf("Timeout() = %v, want %v", got, tt.timeout)
+			}
+			if got := tt.err.Temporary(); got != tt.temporary {
+				t.Errorf("Temporary() = %v, want %v", got, tt.temporary)
+			}
+		})
+	}
+}
+
+func TestConnectionStatusChangedInfo_Err(t *testing.T) {
+	info := &ConnectionStatusChangedInfo{Connection: 3, NewState: ConnectionStateConnected}
+	if err := info.Err(); err != nil {
+		t.Errorf("Err() for connected = %v, want nil", err)
+	}
+
+	info = &ConnectionStatusChangedInfo{
+		Connection: 3,
+		Identity:   NewIdentityFromSteamID(76561198000000000),
+		NewState:   ConnectionStateProblemDetectedLocally,
+		EndReason:  EndReasonRemoteTimeout,
+	}
+	err := info.Err()
+	if !IsNotConnected(err) {
+		t.Errorf("Err() = %v, want ErrNotConnected", err)
+	}
+	var netErr net.Error
+	if !errors.As(err, &netErr) || !netErr.Timeout() {
+		t.Errorf("Err() Timeout() = false, want true")
+	}
+	want := "steamnet: connection closed connection 3 steamid:76561198000000000: not connected, end reason RemoteTimeout (4001)"
+	if err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
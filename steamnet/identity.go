@@ -1,29 +1,89 @@
 package steamnet
 
 import (
+	"bytes"
+	"encoding"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"net"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"github.com/guowei-gong/steamkit-go"
 )
 
 // IdentityType 表示身份类型
 type IdentityType int
 
 const (
-	IdentityTypeInvalid IdentityType = 0 // 无效身份
-	IdentityTypeSteamID IdentityType = 1 // SteamID
-	IdentityTypeIPAddr  IdentityType = 2 // IP 地址
+	IdentityTypeInvalid        IdentityType = 0 // 无效身份
+	IdentityTypeSteamID        IdentityType = 1 // SteamID
+	IdentityTypeIPAddr         IdentityType = 2 // IP 地址
+	IdentityTypeGenericString  IdentityType = 3 // 应用自定义字符串
+	IdentityTypeGenericBytes   IdentityType = 4 // 应用自定义字节
+	IdentityTypeXboxPairwiseID IdentityType = 5 // Xbox Pairwise ID
+	IdentityTypeSonyPSN        IdentityType = 6 // PlayStation Network ID
+	IdentityTypeGoogleStadia   IdentityType = 7 // Google Stadia ID
+	IdentityTypeUnknown        IdentityType = 8 // 无法识别的类型，保留原始字符串
 )
 
-// Identity 表示网络端点的身份
+// String 返回身份类型的名称
+func (t IdentityType) String() string {
+	switch t {
+	case IdentityTypeInvalid:
+		return "Invalid"
+	case IdentityTypeSteamID:
+		return "SteamID"
+	case IdentityTypeIPAddr:
+		return "IPAddr"
+	case IdentityTypeGenericString:
+		return "GenericString"
+	case IdentityTypeGenericBytes:
+		return "GenericBytes"
+	case IdentityTypeXboxPairwiseID:
+		return "XboxPairwiseID"
+	case IdentityTypeSonyPSN:
+		return "SonyPSN"
+	case IdentityTypeGoogleStadia:
+		return "GoogleStadia"
+	case IdentityTypeUnknown:
+		return "Unknown"
+	default:
+		return fmt.Sprintf("IdentityType(%d)", int(t))
+	}
+}
+
+// 各身份类型的长度限制（与 steamnetworkingtypes.h 一致）
+const (
+	maxGenericStringLen  = 31  // k_cchMaxGenericString - 1
+	maxGenericBytesLen   = 32  // k_cbMaxGenericBytes
+	maxXboxPairwiseIDLen = 32  // k_cchMaxXboxPairwiseID - 1
+	maxUnknownStringLen  = 127 // m_szUnknownRawString 的容量 - 1
+)
+
+// Identity 表示网络端点的身份（对应 SteamNetworkingIdentity）
 type Identity struct {
 	identityType IdentityType
-	steamID      uint64
+	steamID      steamkit.SteamID
 	ipAddr       string
 	port         uint16
+	str          string // 通用字符串、通用字节、Xbox ID 或未知类型的原始字符串
+	id64         uint64 // PSN / Stadia ID
 }
 
+var (
+	_ net.Addr                 = Identity{}
+	_ encoding.TextMarshaler   = Identity{}
+	_ encoding.TextUnmarshaler = (*Identity)(nil)
+	_ json.Marshaler           = Identity{}
+	_ json.Unmarshaler         = (*Identity)(nil)
+)
+
 // NewIdentityFromSteamID 从 SteamID 创建身份
-func NewIdentityFromSteamID(steamID uint64) Identity {
+func NewIdentityFromSteamID(steamID steamkit.SteamID) Identity {
 	return Identity{
 		identityType: IdentityTypeSteamID,
 		steamID:      steamID,
@@ -31,7 +91,12 @@ func NewIdentityFromSteamID(steamID uint64) Identity {
 }
 
 // NewIdentityFromIPAddr 从 IP 地址创建身份
+// 可解析的地址会转换为规范形式（例如 "::ffff:1.2.3.4" 变为 "1.2.3.4"，"::" 变为空字符串），
+// 因此相同的地址得到相同的 Identity，可以直接用作 map 的键
 func NewIdentityFromIPAddr(ip string, port uint16) Identity {
+	if addr, err := netip.ParseAddr(ip); err == nil {
+		ip = canonicalIP(addr)
+	}
 	return Identity{
 		identityType: IdentityTypeIPAddr,
 		ipAddr:       ip,
@@ -39,6 +104,56 @@ func NewIdentityFromIPAddr(ip string, port uint16) Identity {
 	}
 }
 
+// NewIdentityFromAddrPort 从 netip.AddrPort 创建 IP 身份
+// IPv6 zone 会被丢弃（原生结构体无法表示），无效的地址返回无效身份
+func NewIdentityFromAddrPort(addrPort netip.AddrPort) Identity {
+	if !addrPort.Addr().IsValid() {
+		return NewInvalidIdentity()
+	}
+	return Identity{
+		identityType: IdentityTypeIPAddr,
+		ipAddr:       canonicalIP(addrPort.Addr()),
+		port:         addrPort.Port(),
+	}
+}
+
+// NewIdentityFromGenericString 从应用自定义字符串创建身份
+// s 不能为空、不能包含 NUL，最长 31 字节
+func NewIdentityFromGenericString(s string) (Identity, error) {
+	if s == "" || len(s) > maxGenericStringLen || strings.IndexByte(s, 0) >= 0 {
+		return NewInvalidIdentity(), &Error{Op: "NewIdentityFromGenericString", Message: fmt.Sprintf("invalid generic string %q", s), Err: ErrInvalidIdentity}
+	}
+	return Identity{identityType: IdentityTypeGenericString, str: s}, nil
+}
+
+// NewIdentityFromGenericBytes 从应用自定义字节创建身份
+// b 不能为空，最长 32 字节
+func NewIdentityFromGenericBytes(b []byte) (Identity, error) {
+	if len(b) == 0 || len(b) > maxGenericBytesLen {
+		return NewInvalidIdentity(), &Error{Op: "NewIdentityFromGenericBytes", Message: fmt.Sprintf("invalid generic bytes length %d", len(b)), Err: ErrInvalidIdentity}
+	}
+	return Identity{identityType: IdentityTypeGenericBytes, str: string(b)}, nil
+}
+
+// NewIdentityFromXboxPairwiseID 从 Xbox Pairwise ID 创建身份
+// id 不能为空、不能包含 NUL，最长 32 字节
+func NewIdentityFromXboxPairwiseID(id string) (Identity, error) {
+	if id == "" || len(id) > maxXboxPairwiseIDLen || strings.IndexByte(id, 0) >= 0 {
+		return NewInvalidIdentity(), &Error{Op: "NewIdentityFromXboxPairwiseID", Message: fmt.Sprintf("invalid Xbox pairwise ID %q", id), Err: ErrInvalidIdentity}
+	}
+	return Identity{identityType: IdentityTypeXboxPairwiseID, str: id}, nil
+}
+
+// NewIdentityFromPSNID 从 PlayStation Network ID 创建身份
+func NewIdentityFromPSNID(id uint64) Identity {
+	return Identity{identityType: IdentityTypeSonyPSN, id64: id}
+}
+
+// NewIdentityFromStadiaID 从 Google Stadia ID 创建身份
+func NewIdentityFromStadiaID(id uint64) Identity {
+	return Identity{identityType: IdentityTypeGoogleStadia, id64: id}
+}
+
 // NewInvalidIdentity 创建无效身份
 func NewInvalidIdentity() Identity {
 	return Identity{
@@ -48,7 +163,7 @@ func NewInvalidIdentity() Identity {
 
 // GetSteamID 获取 SteamID
 // 如果身份类型不是 SteamID，返回 0
-func (i Identity) GetSteamID() uint64 {
+func (i Identity) GetSteamID() steamkit.SteamID {
 	if i.identityType == IdentityTypeSteamID {
 		return i.steamID
 	}
@@ -64,6 +179,67 @@ func (i Identity) GetIPAddr() (string, uint16) {
 	return "", 0
 }
 
+// GetGenericString 获取应用自定义字符串
+// 如果身份类型不是通用字符串，返回空字符串
+func (i Identity) GetGenericString() string {
+	if i.identityType == IdentityTypeGenericString {
+		return i.str
+	}
+	return ""
+}
+
+// GetGenericBytes 获取应用自定义字节（副本）
+// 如果身份类型不是通用字节，返回 nil
+func (i Identity) GetGenericBytes() []byte {
+	if i.identityType == IdentityTypeGenericBytes {
+		return []byte(i.str)
+	}
+	return nil
+}
+
+// GetXboxPairwiseID 获取 Xbox Pairwise ID
+// 如果身份类型不是 Xbox，返回空字符串
+func (i Identity) GetXboxPairwiseID() string {
+	if i.identityType == IdentityTypeXboxPairwiseID {
+		return i.str
+	}
+	return ""
+}
+
+// GetPSNID 获取 PlayStation Network ID
+// 如果身份类型不是 PSN，返回 0
+func (i Identity) GetPSNID() uint64 {
+	if i.identityType == IdentityTypeSonyPSN {
+		return i.id64
+	}
+	return 0
+}
+
+// GetStadiaID 获取 Google Stadia ID
+// 如果身份类型不是 Stadia，返回 0
+func (i Identity) GetStadiaID() uint64 {
+	if i.identityType == IdentityTypeGoogleStadia {
+		return i.id64
+	}
+	return 0
+}
+
+// AddrPort 将 IP 身份转换为 netip.AddrPort
+// 空地址转换为 "::"，如果身份类型不是 IP 地址或地址无法解析，返回 false
+func (i Identity) AddrPort() (netip.AddrPort, bool) {
+	if i.identityType != IdentityTypeIPAddr {
+		return netip.AddrPort{}, false
+	}
+	if i.ipAddr == "" {
+		return netip.AddrPortFrom(netip.IPv6Unspecified(), i.port), true
+	}
+	addr, err := netip.ParseAddr(i.ipAddr)
+	if err != nil {
+		return netip.AddrPort{}, false
+	}
+	return netip.AddrPortFrom(addr, i.port), true
+}
+
 // Type 返回身份类型
 func (i Identity) Type() IdentityType {
 	return i.identityType
@@ -74,18 +250,99 @@ func (i Identity) IsValid() bool {
 	return i.identityType != IdentityTypeInvalid
 }
 
-// String 返回身份的字符串表示
+// String 返回与 SteamNetworkingIdentity::ToString 相同格式的字符串，
+// 例如 "steamid:76561198000000000"、"ip:192.168.1.1:27015"、"str:name"、"gen:0a0b"
+// 结果可以由 ParseIdentity 解析
 func (i Identity) String() string {
 	switch i.identityType {
+	case IdentityTypeInvalid:
+		return "invalid"
 	case IdentityTypeSteamID:
-		return fmt.Sprintf("SteamID:%d", i.steamID)
+		return "steamid:" + strconv.FormatUint(uint64(i.steamID), 10)
 	case IdentityTypeIPAddr:
-		return fmt.Sprintf("IP:%s:%d", i.ipAddr, i.port)
-	case IdentityTypeInvalid:
-		return "Invalid"
+		return "ip:" + formatIPAddr(i.ipAddr, i.port)
+	case IdentityTypeGenericString:
+		return "str:" + i.str
+	case IdentityTypeGenericBytes:
+		return "gen:" + hex.EncodeToString([]byte(i.str))
+	case IdentityTypeXboxPairwiseID:
+		return "xboxid:" + i.str
+	case IdentityTypeSonyPSN:
+		return "psn:" + strconv.FormatUint(i.id64, 10)
+	case IdentityTypeGoogleStadia:
+		return "stadia:" + strconv.FormatUint(i.id64, 10)
+	case IdentityTypeUnknown:
+		return i.str
 	default:
-		return "Unknown"
+		return fmt.Sprintf("IdentityType(%d)", int(i.identityType))
+	}
+}
+
+// Network 实现 net.Addr，返回 "steamnet"
+func (i Identity) Network() string {
+	return "steamnet"
+}
+
+// MarshalText 实现 encoding.TextMarshaler，输出与 String 相同的格式
+// 无效身份输出 "invalid"；无法表示的值（例如无法解析的 IP 地址）返回错误，保证输出可以被 UnmarshalText 还原
+func (i Identity) MarshalText() ([]byte, error) {
+	if i.identityType == IdentityTypeIPAddr && i.ipAddr != "" {
+		if _, err := netip.ParseAddr(i.ipAddr); err != nil {
+			return nil, &Error{Op: "MarshalText", Message: fmt.Sprintf("invalid IP address %q", i.ipAddr), Err: ErrInvalidIdentity}
+		}
+	}
+	if _, err := marshalIdentity(i); err != nil {
+		return nil, withOp("MarshalText", err)
+	}
+	return []byte(i.String()), nil
+}
+
+// UnmarshalText 实现 encoding.TextUnmarshaler，接受 ParseIdentity 支持的格式
+// "invalid" 和空字符串解析为无效身份
+func (i *Identity) UnmarshalText(text []byte) error {
+	if len(text) == 0 || string(text) == "invalid" {
+		*i = NewInvalidIdentity()
+		return nil
+	}
+	v, err := ParseIdentity(string(text))
+	if err != nil {
+		return err
+	}
+	*i = v
+	return nil
+}
+
+// MarshalJSON 实现 json.Marshaler，输出为字符串
+func (i Identity) MarshalJSON() ([]byte, error) {
+	text, err := i.MarshalText()
+	if err != nil {
+		return nil, err
 	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler，只接受字符串和 null
+func (i *Identity) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return &Error{Op: "UnmarshalJSON", Message: fmt.Sprintf("identity must be a JSON string: %v", err), Err: ErrInvalidIdentity}
+	}
+	return i.UnmarshalText([]byte(s))
+}
+
+// formatIPAddr 按 SteamNetworkingIPAddr::ToString 的格式输出地址
+// 端口为 0 时省略端口，带端口的 IPv6 地址使用方括号
+func formatIPAddr(ip string, port uint16) string {
+	if ip == "" {
+		ip = "::"
+	}
+	if port == 0 {
+		return ip
+	}
+	return net.JoinHostPort(ip, strconv.Itoa(int(port)))
 }
 
 // Equal 比较两个身份是否相等
@@ -99,6 +356,10 @@ func (i Identity) Equal(other Identity) bool {
 		return i.steamID == other.steamID
 	case IdentityTypeIPAddr:
 		return i.ipAddr == other.ipAddr && i.port == other.port
+	case IdentityTypeGenericString, IdentityTypeGenericBytes, IdentityTypeXboxPairwiseID, IdentityTypeUnknown:
+		return i.str == other.str
+	case IdentityTypeSonyPSN, IdentityTypeGoogleStadia:
+		return i.id64 == other.id64
 	case IdentityTypeInvalid:
 		return true
 	default:
@@ -106,30 +367,181 @@ func (i Identity) Equal(other Identity) bool {
 	}
 }
 
-// ParseIdentity 从字符串解析身份
+// ParseIdentity 从字符串解析身份，格式与 SteamNetworkingIdentity::ParseString 兼容
 // 支持格式：
-//   - "steamid:76561198000000000" - SteamID
-//   - "ip:192.168.1.1:27015" - IP 地址
+//   - "steamid:76561198000000000" - SteamID（也接受 steamkit.ParseSteamID 支持的其他格式）
+//   - "ip:192.168.1.1:27015"、"ip:[::1]:27015"、"ip:::1" - IP 地址（端口可省略）
+//   - "str:name" - 通用字符串
+//   - "gen:0a0b0c" - 通用字节（十六进制）
+//   - "xboxid:..."、"psn:123"、"stadia:123" - 平台身份
+//
+// 其他 "类型:值" 形式的字符串解析为 IdentityTypeUnknown，保留原始字符串
 func ParseIdentity(s string) (Identity, error) {
-	// 尝试解析为 SteamID
-	var steamID uint64
-	if n, _ := fmt.Sscanf(s, "steamid:%d", &steamID); n == 1 {
-		return NewIdentityFromSteamID(steamID), nil
+	prefix, rest, ok := strings.Cut(s, ":")
+	if !ok {
+		return NewInvalidIdentity(), &Error{Op: "ParseIdentity", Message: fmt.Sprintf("invalid identity format %q", s), Err: ErrInvalidIdentity}
 	}
 
-	// 尝试解析为 IP 地址
-	var ip string
-	var port uint16
-	if n, _ := fmt.Sscanf(s, "ip:%s", &ip); n == 1 {
-		// 解析 IP:Port
-		host, portStr, err := net.SplitHostPort(ip)
+	switch prefix {
+	case "steamid":
+		steamID, err := steamkit.ParseSteamID(rest)
+		if err != nil {
+			return NewInvalidIdentity(), &Error{Op: "ParseIdentity", Message: err.Error(), Err: ErrInvalidIdentity}
+		}
+		return NewIdentityFromSteamID(steamID), nil
+	case "ip":
+		addr, port, err := parseIPAddr(rest)
 		if err != nil {
-			return NewInvalidIdentity(), fmt.Errorf("invalid IP address format: %w", err)
+			return NewInvalidIdentity(), withOp("ParseIdentity", err)
 		}
-		if n, _ := fmt.Sscanf(portStr, "%d", &port); n == 1 {
-			return NewIdentityFromIPAddr(host, port), nil
+		return NewIdentityFromAddrPort(netip.AddrPortFrom(addr, port)), nil
+	case "str":
+		return NewIdentityFromGenericString(rest)
+	case "gen":
+		b, err := hex.DecodeString(rest)
+		if err != nil {
+			return NewInvalidIdentity(), &Error{Op: "ParseIdentity", Message: fmt.Sprintf("invalid generic bytes %q", rest), Err: ErrInvalidIdentity}
+		}
+		return NewIdentityFromGenericBytes(b)
+	case "xboxid":
+		return NewIdentityFromXboxPairwiseID(rest)
+	case "psn", "stadia":
+		id, err := strconv.ParseUint(rest, 10, 64)
+		if err != nil {
+			return NewInvalidIdentity(), &Error{Op: "ParseIdentity", Message: fmt.Sprintf("invalid %s ID %q", prefix, rest), Err: ErrInvalidIdentity}
 		}
+		if prefix == "psn" {
+			return NewIdentityFromPSNID(id), nil
+		}
+		return NewIdentityFromStadiaID(id), nil
+	}
+
+	// 与原生实现一致：无法识别的类型保留原始字符串
+	if prefix == "" || rest == "" || len(s) > maxUnknownStringLen || strings.IndexByte(s, 0) >= 0 {
+		return NewInvalidIdentity(), &Error{Op: "ParseIdentity", Message: fmt.Sprintf("invalid identity format %q", s), Err: ErrInvalidIdentity}
 	}
+	return Identity{identityType: IdentityTypeUnknown, str: s}, nil
+}
 
-	return NewInvalidIdentity(), fmt.Errorf("invalid identity format: %s", s)
+// identitySize 是 SteamNetworkingIdentity 的大小
+// 结构体布局:
+//
+//	offset 0: ESteamNetworkingIdentityType m_eType (int32)
+//	offset 4: int m_cbSize (int32)，union 中有效数据的字节数
+//	offset 8: union (128 bytes)
+//	          m_steamID64 / m_PSNID / m_stadiaID (uint64)
+//	          m_ip (SteamNetworkingIPAddr, 18 bytes)
+//	          m_szGenericString[32] / m_genericBytes[32] / m_szXboxPairwiseID[33]
+//	          m_szUnknownRawString[128]
+const identitySize = 136
+
+// identityDataOffset 是 union 在 SteamNetworkingIdentity 中的偏移
+const identityDataOffset = 8
+
+// 原生 ESteamNetworkingIdentityType 取值
+const (
+	nativeIdentityTypeInvalid        = 0
+	nativeIdentityTypeIPAddress      = 1
+	nativeIdentityTypeGenericString  = 2
+	nativeIdentityTypeGenericBytes   = 3
+	nativeIdentityTypeUnknownType    = 4
+	nativeIdentityTypeSteamID        = 16
+	nativeIdentityTypeXboxPairwiseID = 17
+	nativeIdentityTypeSonyPSN        = 18
+	nativeIdentityTypeGoogleStadia   = 19
+)
+
+// marshalIdentity 将身份编组为 SteamNetworkingIdentity
+// 无效身份编组为全零结构体（k_ESteamNetworkingIdentityType_Invalid）
+func marshalIdentity(identity Identity) ([identitySize]byte, error) {
+	var b [identitySize]byte
+	data := b[identityDataOffset:]
+
+	var nativeType, size uint32
+	switch identity.identityType {
+	case IdentityTypeInvalid:
+		return b, nil
+	case IdentityTypeSteamID:
+		nativeType, size = nativeIdentityTypeSteamID, 8
+		binary.LittleEndian.PutUint64(data, uint64(identity.steamID))
+	case IdentityTypeIPAddr:
+		addr, err := marshalIPAddr(identity.ipAddr, identity.port)
+		if err != nil {
+			return b, err
+		}
+		nativeType, size = nativeIdentityTypeIPAddress, ipAddrSize
+		copy(data, addr[:])
+	case IdentityTypeGenericString:
+		nativeType, size = nativeIdentityTypeGenericString, uint32(len(identity.str)+1)
+		copy(data, identity.str)
+	case IdentityTypeGenericBytes:
+		nativeType, size = nativeIdentityTypeGenericBytes, uint32(len(identity.str))
+		copy(data, identity.str)
+	case IdentityTypeXboxPairwiseID:
+		nativeType, size = nativeIdentityTypeXboxPairwiseID, uint32(len(identity.str)+1)
+		copy(data, identity.str)
+	case IdentityTypeSonyPSN:
+		nativeType, size = nativeIdentityTypeSonyPSN, 8
+		binary.LittleEndian.PutUint64(data, identity.id64)
+	case IdentityTypeGoogleStadia:
+		nativeType, size = nativeIdentityTypeGoogleStadia, 8
+		binary.LittleEndian.PutUint64(data, identity.id64)
+	case IdentityTypeUnknown:
+		nativeType, size = nativeIdentityTypeUnknownType, uint32(len(identity.str)+1)
+		copy(data, identity.str)
+	default:
+		return b, &Error{Op: "marshalIdentity", Message: fmt.Sprintf("unknown identity type %d", int(identity.identityType)), Err: ErrInvalidIdentity}
+	}
+
+	// 构造函数已检查长度，这里防御直接构造的值越界
+	if size > identitySize-identityDataOffset {
+		return b, &Error{Op: "marshalIdentity", Message: "identity data too large", Err: ErrInvalidIdentity}
+	}
+
+	binary.LittleEndian.PutUint32(b[0:], nativeType)
+	binary.LittleEndian.PutUint32(b[4:], size)
+	return b, nil
+}
+
+// unmarshalIdentity 从 SteamNetworkingIdentity 解码身份
+// 不支持的身份类型返回无效身份
+func unmarshalIdentity(b []byte) Identity {
+	if len(b) < identitySize {
+		return NewInvalidIdentity()
+	}
+
+	size := int(int32(binary.LittleEndian.Uint32(b[4:])))
+	data := b[identityDataOffset:identitySize]
+
+	switch binary.LittleEndian.Uint32(b[0:]) {
+	case nativeIdentityTypeSteamID:
+		return NewIdentityFromSteamID(steamkit.SteamID(binary.LittleEndian.Uint64(data)))
+	case nativeIdentityTypeIPAddress:
+		ip, port := unmarshalIPAddr(data[:ipAddrSize])
+		return NewIdentityFromIPAddr(ip, port)
+	case nativeIdentityTypeGenericString:
+		identity, _ := NewIdentityFromGenericString(cString(data[:maxGenericStringLen+1]))
+		return identity
+	case nativeIdentityTypeGenericBytes:
+		if size <= 0 || size > maxGenericBytesLen {
+			return NewInvalidIdentity()
+		}
+		identity, _ := NewIdentityFromGenericBytes(bytes.Clone(data[:size]))
+		return identity
+	case nativeIdentityTypeXboxPairwiseID:
+		identity, _ := NewIdentityFromXboxPairwiseID(cString(data[:maxXboxPairwiseIDLen+1]))
+		return identity
+	case nativeIdentityTypeSonyPSN:
+		return NewIdentityFromPSNID(binary.LittleEndian.Uint64(data))
+	case nativeIdentityTypeGoogleStadia:
+		return NewIdentityFromStadiaID(binary.LittleEndian.Uint64(data))
+	case nativeIdentityTypeUnknownType:
+		raw := cString(data)
+		if raw == "" {
+			return NewInvalidIdentity()
+		}
+		return Identity{identityType: IdentityTypeUnknown, str: raw}
+	default:
+		return NewInvalidIdentity()
+	}
 }
@@ -1,11 +1,19 @@
 package steamnet
 
 import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"net"
+	"net/netip"
+	"strings"
 	"testing"
+
+	"github.com/guowei-gong/steamkit-go"
 )
 
 func TestNewIdentityFromSteamID(t *testing.T) {
-	steamID := uint64(76561198000000000)
+	steamID := steamkit.SteamID(76561198000000000)
 	identity := NewIdentityFromSteamID(steamID)
 
 	if identity.Type() != IdentityTypeSteamID {
@@ -65,17 +73,52 @@ func TestIdentity_String(t *testing.T) {
 		{
 			name:     "SteamID",
 			identity: NewIdentityFromSteamID(76561198000000000),
-			expected: "SteamID:76561198000000000",
+			expected: "steamid:76561198000000000",
 		},
 		{
 			name:     "IPAddr",
 			identity: NewIdentityFromIPAddr("192.168.1.1", 27015),
-			expected: "IP:192.168.1.1:27015",
+			expected: "ip:192.168.1.1:27015",
 		},
 		{
 			name:     "Invalid",
 			identity: NewInvalidIdentity(),
-			expected: "Invalid",
+			expected: "invalid",
+		},
+		{
+			name:     "IPv6",
+			identity: NewIdentityFromIPAddr("::1", 27015),
+			expected: "ip:[::1]:27015",
+		},
+		{
+			name:     "IPv4NoPort",
+			identity: NewIdentityFromIPAddr("10.0.0.1", 0),
+			expected: "ip:10.0.0.1",
+		},
+		{
+			name:     "GenericString",
+			identity: mustIdentity(NewIdentityFromGenericString("lobby-host")),
+			expected: "str:lobby-host",
+		},
+		{
+			name:     "GenericBytes",
+			identity: mustIdentity(NewIdentityFromGenericBytes([]byte{0x0a, 0xff})),
+			expected: "gen:0aff",
+		},
+		{
+			name:     "XboxPairwiseID",
+			identity: mustIdentity(NewIdentityFromXboxPairwiseID("abc123")),
+			expected: "xboxid:abc123",
+		},
+		{
+			name:     "SonyPSN",
+			identity: NewIdentityFromPSNID(42),
+			expected: "psn:42",
+		},
+		{
+			name:     "GoogleStadia",
+			identity: NewIdentityFromStadiaID(43),
+			expected: "stadia:43",
 		},
 	}
 
@@ -136,6 +179,18 @@ func TestParseIdentity(t *testing.T) {
 			wantValid: true,
 			wantType:  IdentityTypeSteamID,
 		},
+		{
+			name:      "ValidSteamID3",
+			input:     "steamid:[U:1:22202]",
+			wantValid: true,
+			wantType:  IdentityTypeSteamID,
+		},
+		{
+			name:      "InvalidSteamID",
+			input:     "steamid:abc",
+			wantValid: false,
+			wantType:  IdentityTypeInvalid,
+		},
 		{
 			name:      "ValidIPAddr",
 			input:     "ip:192.168.1.1:27015",
@@ -148,6 +203,26 @@ func TestParseIdentity(t *testing.T) {
 			wantValid: false,
 			wantType:  IdentityTypeInvalid,
 		},
+		{"IPv4NoPort", "ip:192.168.1.1", true, IdentityTypeIPAddr},
+		{"IPv6", "ip:::1", true, IdentityTypeIPAddr},
+		{"IPv6Brackets", "ip:[::1]:27015", true, IdentityTypeIPAddr},
+		{"InvalidPort", "ip:192.168.1.1:70000", false, IdentityTypeInvalid},
+		{"InvalidIP", "ip:example.com", false, IdentityTypeInvalid},
+		{"IPv6Zone", "ip:[fe80::1%eth0]:27015", false, IdentityTypeInvalid},
+		{"IPv6MissingBracket", "ip:[::1:27015", false, IdentityTypeInvalid},
+		{"IPv6GarbageAfterBracket", "ip:[::1]x", false, IdentityTypeInvalid},
+		{"IPv4Bracketed", "ip:[1.2.3.4]:27015", false, IdentityTypeInvalid},
+		{"EmptyPort", "ip:1.2.3.4:", false, IdentityTypeInvalid},
+		{"PortLeadingZero", "ip:1.2.3.4:027015", false, IdentityTypeInvalid},
+		{"PortSign", "ip:1.2.3.4:+80", false, IdentityTypeInvalid},
+		{"GenericString", "str:lobby-host", true, IdentityTypeGenericString},
+		{"GenericStringTooLong", "str:" + strings.Repeat("a", 32), false, IdentityTypeInvalid},
+		{"GenericBytes", "gen:0aff", true, IdentityTypeGenericBytes},
+		{"GenericBytesBadHex", "gen:0g", false, IdentityTypeInvalid},
+		{"XboxPairwiseID", "xboxid:abc123", true, IdentityTypeXboxPairwiseID},
+		{"SonyPSN", "psn:42", true, IdentityTypeSonyPSN},
+		{"GoogleStadia", "stadia:43", true, IdentityTypeGoogleStadia},
+		{"Unknown", "future:value", true, IdentityTypeUnknown},
 	}
 
 	for _, tt := range tests {
@@ -168,3 +243,247 @@ func TestParseIdentity(t *testing.T) {
 		})
 	}
 }
+
+func TestUnmarshalIdentity(t *testing.T) {
+	var steamID [identitySize]byte
+	binary.LittleEndian.PutUint32(steamID[0:], nativeIdentityTypeSteamID)
+	binary.LittleEndian.PutUint32(steamID[4:], 8)
+	binary.LittleEndian.PutUint64(steamID[8:], 76561198000000000)
+
+	var ipAddr [identitySize]byte
+	binary.LittleEndian.PutUint32(ipAddr[0:], nativeIdentityTypeIPAddress)
+	binary.LittleEndian.PutUint32(ipAddr[4:], ipAddrSize)
+	addr, _ := marshalIPAddr("192.168.1.1", 27015)
+	copy(ipAddr[8:], addr[:])
+
+	tests := []struct {
+		name     string
+		input    []byte
+		expected Identity
+	}{
+		{"SteamID", steamID[:], NewIdentityFromSteamID(76561198000000000)},
+		{"IPAddr", ipAddr[:], NewIdentityFromIPAddr("192.168.1.1", 27015)},
+		{"Invalid", make([]byte, identitySize), NewInvalidIdentity()},
+		{"Short", steamID[:8], NewInvalidIdentity()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := unmarshalIdentity(tt.input); !got.Equal(tt.expected) {
+				t.Errorf("unmarshalIdentity() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func mustIdentity(identity Identity, err error) Identity {
+	if err != nil {
+		panic(err)
+	}
+	return identity
+}
+
+func TestNewIdentity_Limits(t *testing.T) {
+	if _, err := NewIdentityFromGenericString(""); !IsInvalidIdentity(err) {
+		t.Errorf("NewIdentityFromGenericString(\"\") error = %v, want ErrInvalidIdentity", err)
+	}
+	if _, err := NewIdentityFromGenericString(strings.Repeat("a", 31)); err != nil {
+		t.Errorf("NewIdentityFromGenericString(31) error = %v", err)
+	}
+	if _, err := NewIdentityFromGenericString(strings.Repeat("a", 32)); !IsInvalidIdentity(err) {
+		t.Errorf("NewIdentityFromGenericString(32) error = %v, want ErrInvalidIdentity", err)
+	}
+	if _, err := NewIdentityFromGenericBytes(make([]byte, 33)); !IsInvalidIdentity(err) {
+		t.Errorf("NewIdentityFromGenericBytes(33) error = %v, want ErrInvalidIdentity", err)
+	}
+	if _, err := NewIdentityFromXboxPairwiseID(strings.Repeat("x", 33)); !IsInvalidIdentity(err) {
+		t.Errorf("NewIdentityFromXboxPairwiseID(33) error = %v, want ErrInvalidIdentity", err)
+	}
+
+	// GetGenericBytes 返回副本
+	identity := mustIdentity(NewIdentityFromGenericBytes([]byte{1, 2, 3}))
+	identity.GetGenericBytes()[0] = 9
+	if got := identity.GetGenericBytes(); !bytes.Equal(got, []byte{1, 2, 3}) {
+		t.Errorf("GetGenericBytes() = %v, want [1 2 3]", got)
+	}
+	if identity.GetGenericString() != "" || identity.GetPSNID() != 0 {
+		t.Error("accessors for other types should return zero values")
+	}
+}
+
+// 测试 String 和 ParseIdentity 互为逆运算
+func TestIdentity_StringRoundTrip(t *testing.T) {
+	identities := []Identity{
+		NewIdentityFromSteamID(76561198000000000),
+		NewIdentityFromIPAddr("192.168.1.1", 27015),
+		NewIdentityFromIPAddr("2001:db8::1", 27015),
+		NewIdentityFromIPAddr("::1", 0),
+		NewIdentityFromIPAddr("", 27015),
+		mustIdentity(NewIdentityFromGenericString("str:with:colons")),
+		mustIdentity(NewIdentityFromGenericBytes([]byte{0, 1, 0xfe})),
+		mustIdentity(NewIdentityFromXboxPairwiseID("abc123")),
+		NewIdentityFromPSNID(42),
+		NewIdentityFromStadiaID(43),
+	}
+
+	for _, identity := range identities {
+		t.Run(identity.String(), func(t *testing.T) {
+			got, err := ParseIdentity(identity.String())
+			if err != nil {
+				t.Fatalf("ParseIdentity(%q) error = %v", identity.String(), err)
+			}
+			if !got.Equal(identity) {
+				t.Errorf("ParseIdentity(%q) = %v, want %v", identity.String(), got, identity)
+			}
+		})
+	}
+}
+
+// 测试编组到 SteamNetworkingIdentity 后能原样解码
+func TestMarshalIdentity_RoundTrip(t *testing.T) {
+	tests := []struct {
+		identity Identity
+		wantType uint32
+		wantSize uint32
+	}{
+		{NewInvalidIdentity(), nativeIdentityTypeInvalid, 0},
+		{NewIdentityFromSteamID(76561198000000000), nativeIdentityTypeSteamID, 8},
+		{NewIdentityFromIPAddr("192.168.1.1", 27015), nativeIdentityTypeIPAddress, ipAddrSize},
+		{NewIdentityFromIPAddr("2001:db8::1", 27015), nativeIdentityTypeIPAddress, ipAddrSize},
+		{mustIdentity(NewIdentityFromGenericString("lobby-host")), nativeIdentityTypeGenericString, 11},
+		{mustIdentity(NewIdentityFromGenericString(strings.Repeat("a", 31))), nativeIdentityTypeGenericString, 32},
+		{mustIdentity(NewIdentityFromGenericBytes(bytes.Repeat([]byte{0xab}, 32))), nativeIdentityTypeGenericBytes, 32},
+		{mustIdentity(NewIdentityFromXboxPairwiseID(strings.Repeat("x", 32))), nativeIdentityTypeXboxPairwiseID, 33},
+		{NewIdentityFromPSNID(42), nativeIdentityTypeSonyPSN, 8},
+		{NewIdentityFromStadiaID(43), nativeIdentityTypeGoogleStadia, 8},
+		{mustIdentity(ParseIdentity("future:value")), nativeIdentityTypeUnknownType, 13},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.identity.String(), func(t *testing.T) {
+			b, err := marshalIdentity(tt.identity)
+			if err != nil {
+				t.Fatalf("marshalIdentity() error = %v", err)
+			}
+			if got := binary.LittleEndian.Uint32(b[0:]); got != tt.wantType {
+				t.Errorf("m_eType = %d, want %d", got, tt.wantType)
+			}
+			if got := binary.LittleEndian.Uint32(b[4:]); got != tt.wantSize {
+				t.Errorf("m_cbSize = %d, want %d", got, tt.wantSize)
+			}
+			if got := unmarshalIdentity(b[:]); !got.Equal(tt.identity) {
+				t.Errorf("unmarshalIdentity() = %v, want %v", got, tt.identity)
+			}
+		})
+	}
+}
+
+func TestNewIdentityFromIPAddr_Canonical(t *testing.T) {
+	if !NewIdentityFromIPAddr("::ffff:192.168.1.1", 27015).Equal(NewIdentityFromIPAddr("192.168.1.1", 27015)) {
+		t.Error("IPv4-mapped address should equal the IPv4 address")
+	}
+	if NewIdentityFromIPAddr("2001:0db8:0:0::1", 1) != NewIdentityFromIPAddr("2001:db8::1", 1) {
+		t.Error("IPv6 addresses should be canonicalized so identities can be map keys")
+	}
+	if ip, _ := NewIdentityFromIPAddr("::", 27015).GetIPAddr(); ip != "" {
+		t.Errorf("GetIPAddr() = %q, want empty for ::", ip)
+	}
+}
+
+func TestIdentity_AddrPort(t *testing.T) {
+	ap := netip.MustParseAddrPort("[2001:db8::1]:27015")
+	identity := NewIdentityFromAddrPort(ap)
+	if identity.String() != "ip:[2001:db8::1]:27015" {
+		t.Errorf("String() = %q", identity.String())
+	}
+	if got, ok := identity.AddrPort(); !ok || got != ap {
+		t.Errorf("AddrPort() = %v, %v, want %v", got, ok, ap)
+	}
+
+	// IPv4 映射地址还原为 IPv4，zone 被丢弃
+	identity = NewIdentityFromAddrPort(netip.MustParseAddrPort("[::ffff:10.0.0.1]:80"))
+	if got, _ := identity.AddrPort(); got != netip.MustParseAddrPort("10.0.0.1:80") {
+		t.Errorf("AddrPort() = %v, want 10.0.0.1:80", got)
+	}
+	identity = NewIdentityFromAddrPort(netip.MustParseAddrPort("[fe80::1%eth0]:80"))
+	if got, _ := identity.AddrPort(); got != netip.MustParseAddrPort("[fe80::1]:80") {
+		t.Errorf("AddrPort() = %v, want [fe80::1]:80", got)
+	}
+
+	if got, ok := NewIdentityFromIPAddr("", 27015).AddrPort(); !ok || got != netip.MustParseAddrPort("[::]:27015") {
+		t.Errorf("AddrPort() = %v, %v, want [::]:27015", got, ok)
+	}
+	if _, ok := NewIdentityFromSteamID(76561198000000000).AddrPort(); ok {
+		t.Error("AddrPort() ok = true for SteamID identity")
+	}
+	if NewIdentityFromAddrPort(netip.AddrPort{}).IsValid() {
+		t.Error("zero AddrPort should give an invalid identity")
+	}
+}
+
+func TestIdentity_NetAddr(t *testing.T) {
+	var addr net.Addr = NewIdentityFromSteamID(76561198000000000)
+	if addr.Network() != "steamnet" {
+		t.Errorf("Network() = %q, want steamnet", addr.Network())
+	}
+	if addr.String() != "steamid:76561198000000000" {
+		t.Errorf("String() = %q", addr.String())
+	}
+}
+
+func TestIdentity_TextJSON(t *testing.T) {
+	type config struct {
+		Peer  Identity            `json:"peer"`
+		Peers map[Identity]string `json:"peers"`
+	}
+
+	in := config{
+		Peer: NewIdentityFromIPAddr("::1", 27015),
+		Peers: map[Identity]string{
+			NewIdentityFromSteamID(76561198000000000):               "alice",
+			mustIdentity(NewIdentityFromGenericBytes([]byte{1, 2})): "bob",
+		},
+	}
+	b, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	want := `{"peer":"ip:[::1]:27015","peers":{"gen:0102":"bob","steamid:76561198000000000":"alice"}}`
+	if string(b) != want {
+		t.Errorf("json.Marshal() = %s, want %s", b, want)
+	}
+
+	var out config
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if out.Peer != in.Peer || len(out.Peers) != 2 || out.Peers[NewIdentityFromSteamID(76561198000000000)] != "alice" {
+		t.Errorf("json.Unmarshal() = %+v, want %+v", out, in)
+	}
+
+	// 无效身份可以往返
+	b, err = json.Marshal(NewInvalidIdentity())
+	if err != nil || string(b) != `"invalid"` {
+		t.Errorf("json.Marshal(invalid) = %s, %v", b, err)
+	}
+	identity := NewIdentityFromPSNID(1)
+	if err := json.Unmarshal(b, &identity); err != nil || identity.IsValid() {
+		t.Errorf("json.Unmarshal(invalid) = %v, %v", identity, err)
+	}
+
+	// null 保持原值，非字符串和格式错误返回 ErrInvalidIdentity
+	identity = NewIdentityFromPSNID(1)
+	if err := json.Unmarshal([]byte("null"), &identity); err != nil || identity != NewIdentityFromPSNID(1) {
+		t.Errorf("json.Unmarshal(null) = %v, %v", identity, err)
+	}
+	for _, input := range []string{`123`, `"ip:[::1"`, `"nope"`} {
+		if err := json.Unmarshal([]byte(input), &identity); !IsInvalidIdentity(err) {
+			t.Errorf("json.Unmarshal(%s) error = %v, want ErrInvalidIdentity", input, err)
+		}
+	}
+
+	// 无法表示的值不会输出
+	if _, err := NewIdentityFromIPAddr("not-an-ip", 1).MarshalText(); !IsInvalidIdentity(err) {
+		t.Errorf("MarshalText() error = %v, want ErrInvalidIdentity", err)
+	}
+}
@@ -0,0 +1,279 @@
+package steamnet
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+// ipAddrSize 是 SteamNetworkingIPAddr 的大小
+// 结构体布局（#pragma pack(1)）:
+//
+//	offset 0:  uint8 m_ipv6[16]（IPv4 以 ::ffff:a.b.c.d 映射形式存储，网络字节序）
+//	offset 16: uint16 m_port（主机字节序）
+const ipAddrSize = 18
+
+// FakeIPType 表示 FakeIP 的类型（对应 ESteamNetworkingFakeIPType）
+type FakeIPType int32
+
+const (
+	FakeIPTypeInvalid    FakeIPType = 0 // 无法判断（不是 IPv4 地址或 Steam API 不可用）
+	FakeIPTypeNotFake    FakeIPType = 1 // 普通 IP 地址
+	FakeIPTypeGlobalIPv4 FakeIPType = 2 // 全局 FakeIP，由 Steam 分配
+	FakeIPTypeLocalIPv4  FakeIPType = 3 // 本地 FakeIP，仅在本进程内有效
+)
+
+// String 返回 FakeIP 类型的名称
+func (t FakeIPType) String() string {
+	switch t {
+	case FakeIPTypeInvalid:
+		return "Invalid"
+	case FakeIPTypeNotFake:
+		return "NotFake"
+	case FakeIPTypeGlobalIPv4:
+		return "GlobalIPv4"
+	case FakeIPTypeLocalIPv4:
+		return "LocalIPv4"
+	default:
+		return fmt.Sprintf("FakeIPType(%d)", int32(t))
+	}
+}
+
+// IPAddr 表示 IPv4 或 IPv6 地址和端口（对应 SteamNetworkingIPAddr）
+// IPv4 地址以 ::ffff:a.b.c.d 映射形式存储，零值表示任意地址（::）和端口 0
+// IPAddr 可以直接比较，也可以用作 map 的键
+type IPAddr struct {
+	IPv6 [16]byte // 网络字节序
+	Port uint16   // 主机字节序
+}
+
+// NewIPAddrIPv4 从主机字节序的 IPv4 地址创建 IPAddr（对应 SetIPv4）
+func NewIPAddrIPv4(ip uint32, port uint16) IPAddr {
+	a := IPAddr{Port: port}
+	a.IPv6[10], a.IPv6[11] = 0xff, 0xff
+	binary.BigEndian.PutUint32(a.IPv6[12:], ip)
+	return a
+}
+
+// IPAddrFromAddrPort 从 netip.AddrPort 创建 IPAddr
+// IPv6 zone 会被丢弃，无效的地址得到任意地址
+func IPAddrFromAddrPort(addrPort netip.AddrPort) IPAddr {
+	a := IPAddr{Port: addrPort.Port()}
+	if addrPort.Addr().IsValid() {
+		a.IPv6 = addrPort.Addr().As16()
+	}
+	return a
+}
+
+// IPAddrFromUDPAddr 从 *net.UDPAddr 创建 IPAddr
+// IP 为空表示任意地址；IP 长度或端口无效时返回 ErrInvalidIdentity
+func IPAddrFromUDPAddr(addr *net.UDPAddr) (IPAddr, error) {
+	if addr == nil {
+		return IPAddr{}, &Error{Op: "IPAddrFromUDPAddr", Message: "nil UDP address", Err: ErrInvalidIdentity}
+	}
+	if addr.Port < 0 || addr.Port > 0xffff {
+		return IPAddr{}, &Error{Op: "IPAddrFromUDPAddr", Message: fmt.Sprintf("port %d out of range", addr.Port), Err: ErrInvalidIdentity}
+	}
+	if len(addr.IP) == 0 {
+		return IPAddr{Port: uint16(addr.Port)}, nil
+	}
+	ip, ok := netip.AddrFromSlice(addr.IP)
+	if !ok {
+		return IPAddr{}, &Error{Op: "IPAddrFromUDPAddr", Message: fmt.Sprintf("invalid IP address %v", addr.IP), Err: ErrInvalidIdentity}
+	}
+	return IPAddrFromAddrPort(netip.AddrPortFrom(ip, uint16(addr.Port))), nil
+}
+
+// ParseIPAddr 解析地址字符串，格式与 SteamNetworkingIPAddr::ParseString 兼容
+// 支持 "1.2.3.4"、"1.2.3.4:27015"、"::1"、"[::1]"、"[::1]:27015"，省略端口时端口为 0
+func ParseIPAddr(s string) (IPAddr, error) {
+	addr, port, err := parseIPAddr(s)
+	if err != nil {
+		return IPAddr{}, err
+	}
+	return IPAddrFromAddrPort(netip.AddrPortFrom(addr, port)), nil
+}
+
+// IsIPv6AllZeros 检查地址是否为任意地址（::），不检查端口
+func (a IPAddr) IsIPv6AllZeros() bool {
+	return a.IPv6 == [16]byte{}
+}
+
+// IsIPv4 检查是否为 IPv4 映射地址
+func (a IPAddr) IsIPv4() bool {
+	return netip.AddrFrom16(a.IPv6).Is4In6()
+}
+
+// GetIPv4 返回主机字节序的 IPv4 地址，不是 IPv4 地址时返回 0
+func (a IPAddr) GetIPv4() uint32 {
+	if !a.IsIPv4() {
+		return 0
+	}
+	return binary.BigEndian.Uint32(a.IPv6[12:])
+}
+
+// IsLocalHost 检查是否为本机回环地址（127.0.0.1 或 ::1）
+func (a IPAddr) IsLocalHost() bool {
+	if a.IsIPv4() {
+		return a.GetIPv4() == 0x7f000001
+	}
+	return netip.AddrFrom16(a.IPv6) == netip.IPv6Loopback()
+}
+
+// GetFakeIPType 返回地址的 FakeIP 类型
+// FakeIP 的分配规则由 Steam 决定，IPv4 地址通过 ISteamNetworkingUtils::GetIPv4FakeIPType 判断；
+// 与 SteamNetworkingIPAddr::GetFakeIPType 一致，不是 IPv4 的地址返回 FakeIPTypeInvalid，
+// Steam API 不可用时同样返回 FakeIPTypeInvalid
+func (a IPAddr) GetFakeIPType() FakeIPType {
+	if !a.IsIPv4() {
+		return FakeIPTypeInvalid
+	}
+	utils, err := GetUtils()
+	if err != nil {
+		return FakeIPTypeInvalid
+	}
+	return utils.GetIPv4FakeIPType(a.GetIPv4())
+}
+
+// IsFakeIP 检查是否为 FakeIP（全局或本地）
+func (a IPAddr) IsFakeIP() bool {
+	t := a.GetFakeIPType()
+	return t == FakeIPTypeGlobalIPv4 || t == FakeIPTypeLocalIPv4
+}
+
+// AddrPort 转换为 netip.AddrPort，IPv4 映射地址转换为 IPv4
+func (a IPAddr) AddrPort() netip.AddrPort {
+	return netip.AddrPortFrom(netip.AddrFrom16(a.IPv6).Unmap(), a.Port)
+}
+
+// UDPAddr 转换为 *net.UDPAddr
+func (a IPAddr) UDPAddr() *net.UDPAddr {
+	return net.UDPAddrFromAddrPort(a.AddrPort())
+}
+
+// ToString 按 SteamNetworkingIPAddr::ToString 的格式输出地址
+// withPort 为 true 时输出端口，IPv6 地址使用方括号（例如 "[::1]:27015"）
+func (a IPAddr) ToString(withPort bool) string {
+	addr := netip.AddrFrom16(a.IPv6).Unmap()
+	if !withPort {
+		return addr.String()
+	}
+	return netip.AddrPortFrom(addr, a.Port).String()
+}
+
+// String 返回带端口的地址字符串，等同于 ToString(true)
+func (a IPAddr) String() string {
+	return a.ToString(true)
+}
+
+// marshal 编组为 SteamNetworkingIPAddr
+func (a IPAddr) marshal() [ipAddrSize]byte {
+	var b [ipAddrSize]byte
+	copy(b[:16], a.IPv6[:])
+	binary.LittleEndian.PutUint16(b[16:], a.Port)
+	return b
+}
+
+// decodeIPAddr 从 SteamNetworkingIPAddr 解码，长度不足时返回零值
+func decodeIPAddr(b []byte) IPAddr {
+	var a IPAddr
+	if len(b) < ipAddrSize {
+		return a
+	}
+	copy(a.IPv6[:], b[:16])
+	a.Port = binary.LittleEndian.Uint16(b[16:])
+	return a
+}
+
+// marshalIPAddr 将 IP 地址和端口编组为 SteamNetworkingIPAddr
+// 空字符串表示任意地址（::）
+func marshalIPAddr(ip string, port uint16) ([ipAddrSize]byte, error) {
+	a := IPAddr{Port: port}
+	if ip != "" {
+		addr, err := netip.ParseAddr(ip)
+		if err != nil {
+			return [ipAddrSize]byte{}, &Error{Op: "marshalIPAddr", Message: fmt.Sprintf("invalid IP address %q", ip), Err: ErrInvalidIdentity}
+		}
+		a = IPAddrFromAddrPort(netip.AddrPortFrom(addr, port))
+	}
+	return a.marshal(), nil
+}
+
+// unmarshalIPAddr 从 SteamNetworkingIPAddr 解码 IP 地址和端口
+// 全零地址返回空字符串
+func unmarshalIPAddr(b []byte) (string, uint16) {
+	if len(b) < ipAddrSize {
+		return "", 0
+	}
+	a := decodeIPAddr(b)
+	return canonicalIP(netip.AddrFrom16(a.IPv6)), a.Port
+}
+
+// marshalIdentityIPAddr 将 IP 类型的身份编组为 SteamNetworkingIPAddr
+func marshalIdentityIPAddr(identity Identity) ([ipAddrSize]byte, error) {
+	if identity.Type() != IdentityTypeIPAddr {
+		return [ipAddrSize]byte{}, &Error{Op: "marshalIdentityIPAddr", Message: "identity is not an IP address", Err: ErrInvalidIdentity}
+	}
+	ip, port := identity.GetIPAddr()
+	return marshalIPAddr(ip, port)
+}
+
+// parseIPAddr 解析 SteamNetworkingIPAddr::ParseString 接受的地址格式
+// 支持 "1.2.3.4"、"1.2.3.4:27015"、"::1"、"[::1]"、"[::1]:27015"，"::" 表示任意地址
+// 不接受带 zone 的 IPv6 地址、带方括号的 IPv4 地址以及超出范围或带前导零的端口
+func parseIPAddr(s string) (netip.Addr, uint16, error) {
+	host, portStr, hasPort := s, "", false
+	switch {
+	case strings.HasPrefix(s, "["):
+		end := strings.IndexByte(s, ']')
+		if end < 0 {
+			return netip.Addr{}, 0, &Error{Op: "ParseIPAddr", Message: fmt.Sprintf("missing ']' in IP address %q", s), Err: ErrInvalidIdentity}
+		}
+		host = s[1:end]
+		if rest := s[end+1:]; rest != "" {
+			if portStr, hasPort = strings.CutPrefix(rest, ":"); !hasPort {
+				return netip.Addr{}, 0, &Error{Op: "ParseIPAddr", Message: fmt.Sprintf("unexpected %q after ']' in IP address %q", rest, s), Err: ErrInvalidIdentity}
+			}
+		}
+	case strings.Count(s, ":") == 1:
+		host, portStr, hasPort = strings.Cut(s, ":")
+	}
+
+	addr, err := netip.ParseAddr(host)
+	if err != nil || addr.Zone() != "" {
+		return netip.Addr{}, 0, &Error{Op: "ParseIPAddr", Message: fmt.Sprintf("invalid IP address %q", host), Err: ErrInvalidIdentity}
+	}
+	if strings.HasPrefix(s, "[") && addr.Is4() {
+		return netip.Addr{}, 0, &Error{Op: "ParseIPAddr", Message: fmt.Sprintf("IPv4 address %q must not be bracketed", host), Err: ErrInvalidIdentity}
+	}
+
+	var port uint16
+	if hasPort {
+		if port, err = parsePort(portStr); err != nil {
+			return netip.Addr{}, 0, err
+		}
+	}
+	return addr, port, nil
+}
+
+// parsePort 严格解析十进制端口号（0-65535）
+func parsePort(s string) (uint16, error) {
+	v, err := strconv.ParseUint(s, 10, 16)
+	if err != nil || s[0] == '+' || (len(s) > 1 && s[0] == '0') {
+		return 0, &Error{Op: "ParseIPAddr", Message: fmt.Sprintf("invalid port %q", s), Err: ErrInvalidIdentity}
+	}
+	return uint16(v), nil
+}
+
+// canonicalIP 返回地址的规范字符串形式
+// IPv4 映射地址还原为 IPv4（与原生存储方式一致），任意地址（::）返回空字符串
+func canonicalIP(addr netip.Addr) string {
+	addr = addr.Unmap().WithZone("")
+	if addr == netip.IPv6Unspecified() {
+		return ""
+	}
+	return addr.String()
+}
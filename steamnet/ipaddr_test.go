@@ -0,0 +1,240 @@
+package steamnet
+
+import (
+	"encoding/binary"
+	"net"
+	"net/netip"
+	"testing"
+)
+
+func TestMarshalIPAddr(t *testing.T) {
+	tests := []struct {
+		name     string
+		ip       string
+		port     uint16
+		wantIPv6 [16]byte
+	}{
+		{
+			name:     "IPv4",
+			ip:       "192.168.1.1",
+			port:     27015,
+			wantIPv6: [16]byte{10: 0xff, 11: 0xff, 12: 192, 13: 168, 14: 1, 15: 1},
+		},
+		{
+			name:     "IPv6",
+			ip:       "2001:db8::1",
+			port:     27016,
+			wantIPv6: [16]byte{0: 0x20, 1: 0x01, 2: 0x0d, 3: 0xb8, 15: 1},
+		},
+		{
+			name: "Any",
+			ip:   "",
+			port: 27017,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr, err := marshalIPAddr(tt.ip, tt.port)
+			if err != nil {
+				t.Fatalf("marshalIPAddr() error = %v", err)
+			}
+
+			var gotIPv6 [16]byte
+			copy(gotIPv6[:], addr[:16])
+			if gotIPv6 != tt.wantIPv6 {
+				t.Errorf("m_ipv6 = %v, want %v", gotIPv6, tt.wantIPv6)
+			}
+			if got := binary.LittleEndian.Uint16(addr[16:]); got != tt.port {
+				t.Errorf("m_port = %d, want %d", got, tt.port)
+			}
+
+			gotIP, gotPort := unmarshalIPAddr(addr[:])
+			if gotIP != tt.ip || gotPort != tt.port {
+				t.Errorf("unmarshalIPAddr() = (%v, %v), want (%v, %v)", gotIP, gotPort, tt.ip, tt.port)
+			}
+		})
+	}
+}
+
+func TestMarshalIPAddr_Invalid(t *testing.T) {
+	if _, err := marshalIPAddr("not-an-ip", 27015); !IsInvalidIdentity(err) {
+		t.Errorf("marshalIPAddr() error = %v, want ErrInvalidIdentity", err)
+	}
+}
+
+func TestMarshalIdentityIPAddr(t *testing.T) {
+	if _, err := marshalIdentityIPAddr(NewIdentityFromSteamID(76561198000000000)); !IsInvalidIdentity(err) {
+		t.Errorf("marshalIdentityIPAddr(SteamID) error = %v, want ErrInvalidIdentity", err)
+	}
+
+	addr, err := marshalIdentityIPAddr(NewIdentityFromIPAddr("::1", 27015))
+	if err != nil {
+		t.Fatalf("marshalIdentityIPAddr() error = %v", err)
+	}
+	if addr[15] != 1 {
+		t.Errorf("m_ipv6[15] = %d, want 1", addr[15])
+	}
+}
+
+func TestIPAddr_IPv4(t *testing.T) {
+	a := NewIPAddrIPv4(0xc0a80101, 27015)
+	if !a.IsIPv4() || a.GetIPv4() != 0xc0a80101 {
+		t.Errorf("IsIPv4() = %v, GetIPv4() = %#x", a.IsIPv4(), a.GetIPv4())
+	}
+	if a.String() != "192.168.1.1:27015" || a.ToString(false) != "192.168.1.1" {
+		t.Errorf("String() = %q, ToString(false) = %q", a.String(), a.ToString(false))
+	}
+
+	// 原生布局与 marshalIPAddr 一致
+	want, _ := marshalIPAddr("192.168.1.1", 27015)
+	if a.marshal() != want {
+		t.Errorf("marshal() = %v, want %v", a.marshal(), want)
+	}
+	if decodeIPAddr(want[:]) != a {
+		t.Errorf("decodeIPAddr() = %v, want %v", decodeIPAddr(want[:]), a)
+	}
+}
+
+func TestIPAddr_IPv6(t *testing.T) {
+	a, err := ParseIPAddr("[2001:db8::1]:27015")
+	if err != nil {
+		t.Fatalf("ParseIPAddr() error = %v", err)
+	}
+	if a.IsIPv4() || a.GetIPv4() != 0 {
+		t.Error("IPv6 address reported as IPv4")
+	}
+	if a.String() != "[2001:db8::1]:27015" || a.ToString(false) != "2001:db8::1" {
+		t.Errorf("String() = %q, ToString(false) = %q", a.String(), a.ToString(false))
+	}
+
+	var zero IPAddr
+	if !zero.IsIPv6AllZeros() || zero.String() != "[::]:0" || zero.ToString(false) != "::" {
+		t.Errorf("zero value: IsIPv6AllZeros() = %v, String() = %q", zero.IsIPv6AllZeros(), zero.String())
+	}
+}
+
+func TestParseIPAddr(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"1.2.3.4", "1.2.3.4:0", false},
+		{"1.2.3.4:27015", "1.2.3.4:27015", false},
+		{"::ffff:1.2.3.4", "1.2.3.4:0", false},
+		{"::1", "[::1]:0", false},
+		{"[::1]", "[::1]:0", false},
+		{"[::1]:27015", "[::1]:27015", false},
+		{"1.2.3.4:65536", "", true},
+		{"[1.2.3.4]:80", "", true},
+		{"[fe80::1%eth0]:80", "", true},
+		{"localhost:80", "", true},
+		{"", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseIPAddr(tt.input)
+			if tt.wantErr {
+				if !IsInvalidIdentity(err) {
+					t.Errorf("ParseIPAddr() error = %v, want ErrInvalidIdentity", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseIPAddr() error = %v", err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("ParseIPAddr() = %v, want %v", got, tt.want)
+			}
+			// ToString 的输出可以被 ParseIPAddr 还原
+			if again, err := ParseIPAddr(got.String()); err != nil || again != got {
+				t.Errorf("ParseIPAddr(%q) = %v, %v, want %v", got.String(), again, err, got)
+			}
+		})
+	}
+}
+
+func TestIPAddr_IsLocalHost(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"127.0.0.1", true},
+		{"::1", true},
+		{"127.0.0.2", false},
+		{"10.0.0.1", false},
+		{"::", false},
+	}
+
+	for _, tt := range tests {
+		a, err := ParseIPAddr(tt.input)
+		if err != nil {
+			t.Fatalf("ParseIPAddr(%q) error = %v", tt.input, err)
+		}
+		if got := a.IsLocalHost(); got != tt.want {
+			t.Errorf("%s IsLocalHost() = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestIPAddr_Conversions(t *testing.T) {
+	ap := netip.MustParseAddrPort("10.0.0.1:27015")
+	a := IPAddrFromAddrPort(ap)
+	if !a.IsIPv4() || a.AddrPort() != ap {
+		t.Errorf("AddrPort() = %v, want %v", a.AddrPort(), ap)
+	}
+
+	udp := a.UDPAddr()
+	if !udp.IP.Equal(net.IPv4(10, 0, 0, 1)) || udp.Port != 27015 {
+		t.Errorf("UDPAddr() = %v, want 10.0.0.1:27015", udp)
+	}
+	back, err := IPAddrFromUDPAddr(udp)
+	if err != nil || back != a {
+		t.Errorf("IPAddrFromUDPAddr() = %v, %v, want %v", back, err, a)
+	}
+
+	// 16 字节形式的 IPv4 地址与 4 字节形式相同
+	back, err = IPAddrFromUDPAddr(&net.UDPAddr{IP: net.IPv4(10, 0, 0, 1).To16(), Port: 27015})
+	if err != nil || back != a {
+		t.Errorf("IPAddrFromUDPAddr(To16) = %v, %v, want %v", back, err, a)
+	}
+
+	// IP 为空表示任意地址
+	if back, err := IPAddrFromUDPAddr(&net.UDPAddr{Port: 80}); err != nil || !back.IsIPv6AllZeros() || back.Port != 80 {
+		t.Errorf("IPAddrFromUDPAddr(nil IP) = %v, %v", back, err)
+	}
+
+	for _, addr := range []*net.UDPAddr{nil, {IP: net.IP{1, 2, 3}}, {IP: net.IPv4(1, 2, 3, 4), Port: 70000}} {
+		if _, err := IPAddrFromUDPAddr(addr); !IsInvalidIdentity(err) {
+			t.Errorf("IPAddrFromUDPAddr(%v) error = %v, want ErrInvalidIdentity", addr, err)
+		}
+	}
+}
+
+func TestIPAddr_FakeIP(t *testing.T) {
+	// 与原生实现一致，不是 IPv4 的地址返回 Invalid
+	for _, s := range []string{"[::1]:1", "[2001:db8::1]:27015", "[::]:0"} {
+		v6 := IPAddrFromAddrPort(netip.MustParseAddrPort(s))
+		if got := v6.GetFakeIPType(); got != FakeIPTypeInvalid {
+			t.Errorf("%s GetFakeIPType() = %v, want Invalid", s, got)
+		}
+		if v6.IsFakeIP() {
+			t.Errorf("%s IsFakeIP() = true, want false", s)
+		}
+	}
+
+	// 未初始化时无法判断
+	a := NewIPAddrIPv4(0xa9fe0001, 1)
+	if got := a.GetFakeIPType(); got != FakeIPTypeInvalid {
+		t.Errorf("GetFakeIPType() = %v, want Invalid", got)
+	}
+	if a.IsFakeIP() {
+		t.Error("IsFakeIP() = true, want false")
+	}
+
+	if FakeIPTypeGlobalIPv4.String() != "GlobalIPv4" || FakeIPType(9).String() != "FakeIPType(9)" {
+		t.Errorf("String() = %q, %q", FakeIPTypeGlobalIPv4, FakeIPType(9))
+	}
+}
@@ -0,0 +1,112 @@
+package steamnet
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/guowei-gong/steamkit-go/internal/purego"
+)
+
+// LaneConfig 描述连接上一个通道（lane）的调度参数
+// 优先级数值越小越优先；相同优先级的通道按权重分配带宽
+type LaneConfig struct {
+	Priority int    // 优先级
+	Weight   uint16 // 权重（必须大于 0）
+}
+
+// LaneStatus 包含单个通道的实时状态（对应 SteamNetConnectionRealTimeLaneStatus_t）
+type LaneStatus struct {
+	PendingUnreliable   int   // 待发送的不可靠数据
+	PendingReliable     int   // 待发送的可靠数据
+	SentUnackedReliable int   // 已发送但未确认的可靠数据
+	QueueTime           int64 // 新消息的预计排队时间（微秒）
+}
+
+// laneStatusSize 是 SteamNetConnectionRealTimeLaneStatus_t 的大小
+// 结构体布局:
+//
+//	offset 0:  int m_cbPendingUnreliable
+//	offset 4:  int m_cbPendingReliable
+//	offset 8:  int m_cbSentUnackedReliable
+//	offset 12: int _reservePad1
+//	offset 16: SteamNetworkingMicroseconds m_usecQueueTime (int64)
+//	offset 24: uint32 reserved[10]
+const laneStatusSize = 64
+
+// parseLaneStatus 解析 SteamNetConnectionRealTimeLaneStatus_t 结构体
+func parseLaneStatus(b []byte) LaneStatus {
+	return LaneStatus{
+		PendingUnreliable:   int(int32(binary.LittleEndian.Uint32(b[0:]))),
+		PendingReliable:     int(int32(binary.LittleEndian.Uint32(b[4:]))),
+		SentUnackedReliable: int(int32(binary.LittleEndian.Uint32(b[8:]))),
+		QueueTime:           int64(binary.LittleEndian.Uint64(b[16:])),
+	}
+}
+
+// marshalLaneConfigs 将通道配置编组为原生的优先级数组和权重数组
+func marshalLaneConfigs(lanes []LaneConfig) ([]int32, []uint16, error) {
+	if len(lanes) == 0 {
+		return nil, nil, &Error{Op: "ConfigureConnectionLanes", Message: "at least one lane is required", Err: ErrInvalidMessage}
+	}
+	if len(lanes) > math.MaxUint16 {
+		return nil, nil, &Error{Op: "ConfigureConnectionLanes", Message: fmt.Sprintf("too many lanes: %d", len(lanes)), Err: ErrInvalidMessage}
+	}
+
+	priorities := make([]int32, len(lanes))
+	weights := make([]uint16, len(lanes))
+	for i, lane := range lanes {
+		if lane.Weight == 0 {
+			return nil, nil, &Error{Op: "ConfigureConnectionLanes", Message: fmt.Sprintf("lane %d weight must be positive", i), Err: ErrInvalidMessage}
+		}
+		priorities[i] = int32(lane.Priority)
+		weights[i] = lane.Weight
+	}
+	return priorities, weights, nil
+}
+
+// laneManager 在 Go 侧记录每个连接配置的通道数
+type laneManager struct {
+	mu    sync.RWMutex
+	lanes map[Connection]int
+}
+
+var (
+	globalLaneManager = &laneManager{
+		lanes: make(map[Connection]int),
+	}
+)
+
+func init() {
+	// Shutdown 之后所有连接关闭，连接句柄可能在下次初始化时被复用
+	purego.OnShutdown(globalLaneManager.reset)
+}
+
+// set 记录连接的通道数
+func (m *laneManager) set(conn Connection, numLanes int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lanes[conn] = numLanes
+}
+
+// get 返回连接的通道数，未配置时返回 0
+func (m *laneManager) get(conn Connection) int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.lanes[conn]
+}
+
+// remove 移除连接的通道记录（连接关闭时调用）
+func (m *laneManager) remove(conn Connection) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.lanes, conn)
+}
+
+// reset 清空所有连接的通道记录
+func (m *laneManager) reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lanes = make(map[Connection]int)
+}
@@ -0,0 +1,77 @@
+package steamnet
+
+import (
+	"encoding/binary"
+	"reflect"
+	"testing"
+)
+
+func TestParseLaneStatus(t *testing.T) {
+	var b [laneStatusSize]byte
+	binary.LittleEndian.PutUint32(b[0:], 100)
+	binary.LittleEndian.PutUint32(b[4:], 2048)
+	binary.LittleEndian.PutUint32(b[8:], 512)
+	binary.LittleEndian.PutUint64(b[16:], 15000)
+
+	got := parseLaneStatus(b[:])
+	want := LaneStatus{
+		PendingUnreliable:   100,
+		PendingReliable:     2048,
+		SentUnackedReliable: 512,
+		QueueTime:           15000,
+	}
+	if got != want {
+		t.Errorf("parseLaneStatus() = %+v, want %+v", got, want)
+	}
+}
+
+func TestMarshalLaneConfigs(t *testing.T) {
+	priorities, weights, err := marshalLaneConfigs([]LaneConfig{
+		{Priority: 0, Weight: 1}, // 语音
+		{Priority: 1, Weight: 3}, // 游戏状态
+		{Priority: 1, Weight: 1}, // 资源下载
+	})
+	if err != nil {
+		t.Fatalf("marshalLaneConfigs() error = %v", err)
+	}
+	if want := []int32{0, 1, 1}; !reflect.DeepEqual(priorities, want) {
+		t.Errorf("priorities = %v, want %v", priorities, want)
+	}
+	if want := []uint16{1, 3, 1}; !reflect.DeepEqual(weights, want) {
+		t.Errorf("weights = %v, want %v", weights, want)
+	}
+}
+
+func TestMarshalLaneConfigs_Invalid(t *testing.T) {
+	if _, _, err := marshalLaneConfigs(nil); !IsInvalidMessage(err) {
+		t.Errorf("marshalLaneConfigs(nil) error = %v, want ErrInvalidMessage", err)
+	}
+	if _, _, err := marshalLaneConfigs([]LaneConfig{{Priority: 0, Weight: 0}}); !IsInvalidMessage(err) {
+		t.Errorf("marshalLaneConfigs(zero weight) error = %v, want ErrInvalidMessage", err)
+	}
+}
+
+func TestLaneManager(t *testing.T) {
+	m := &laneManager{lanes: make(map[Connection]int)}
+	conn := Connection(1)
+
+	if got := m.get(conn); got != 0 {
+		t.Errorf("get() = %d, want 0 before set", got)
+	}
+
+	m.set(conn, 3)
+	if got := m.get(conn); got != 3 {
+		t.Errorf("get() = %d, want 3", got)
+	}
+
+	m.remove(conn)
+	if got := m.get(conn); got != 0 {
+		t.Errorf("get() = %d, want 0 after remove", got)
+	}
+
+	m.set(conn, 2)
+	m.reset()
+	if got := m.get(conn); got != 0 {
+		t.Errorf("get() = %d, want 0 after reset", got)
+	}
+}
@@ -0,0 +1,112 @@
+package steamnet
+
+import (
+	"log"
+	"runtime"
+	"runtime/debug"
+	"sync/atomic"
+
+	"github.com/guowei-gong/steamkit-go/internal/purego"
+)
+
+// 消息所有权模型：
+//   - ReceiveMessagesOnConnection / ReceiveMessagesOnPollGroup 返回的每条消息都持有一个
+//     原生 SteamNetworkingMessage_t，调用方必须恰好调用一次 Release（重复调用是安全的）
+//   - Message.Data 在接收时已复制到 Go 内存，Release 之后仍然可以使用
+//   - Release 可以在任意 goroutine 中调用
+//   - Shutdown 之后原生消息已失效，此时 Release 只标记消息为已释放，不再调用原生函数
+
+// releaseNativeMessage 释放原生消息，测试时可替换
+var releaseNativeMessage = purego.CallReleaseMessage
+
+// Release 释放消息的原生内存
+// 必须对每个接收到的消息调用此方法，重复调用是安全的
+// 消息在 Shutdown 之前接收时（句柄已失效）只标记为已释放
+func (m *Message) Release() {
+	if m == nil || !m.released.CompareAndSwap(false, true) {
+		return
+	}
+	if m.leakMeta != nil {
+		runtime.SetFinalizer(m, nil)
+	}
+	if m.cPtr != 0 && m.generation == purego.Generation() {
+		releaseNativeMessage(m.cPtr)
+	}
+}
+
+// IsReleased 检查消息是否已释放
+func (m *Message) IsReleased() bool {
+	return m.released.Load()
+}
+
+// ReleaseMessage 释放消息内存（辅助函数）
+// 等价于 msg.Release()，msg 为 nil 时不做任何操作
+func ReleaseMessage(msg *Message) {
+	msg.Release()
+}
+
+// MessageLeak 描述一条未调用 Release 就被垃圾回收的消息
+type MessageLeak struct {
+	Connection Connection // 来源连接
+	Size       int        // 消息数据大小
+	Stack      string     // 接收消息时的调用栈
+}
+
+// MessageLeakHandler 是消息泄漏的报告函数
+type MessageLeakHandler func(leak MessageLeak)
+
+// messageLeakMeta 保存泄漏检测所需的信息
+type messageLeakMeta struct {
+	stack   string
+	handler MessageLeakHandler
+}
+
+// leakHandler 是当前的泄漏报告函数，nil 表示未启用泄漏检测
+var leakHandler atomic.Pointer[MessageLeakHandler]
+
+// SetMessageLeakHandler 启用消息泄漏检测（调试模式）
+// 启用后，每条接收到的消息都会记录接收时的调用栈并注册 finalizer；
+// 如果消息在未调用 Release 的情况下被回收，会调用 handler 报告并释放原生内存。
+// 传入 nil 关闭泄漏检测。此模式开销较大，只应在开发和测试中使用。
+func SetMessageLeakHandler(handler MessageLeakHandler) {
+	if handler == nil {
+		leakHandler.Store(nil)
+		return
+	}
+	leakHandler.Store(&handler)
+}
+
+// LogMessageLeak 是将泄漏写入标准日志的 MessageLeakHandler
+func LogMessageLeak(leak MessageLeak) {
+	log.Printf("steamnet: message from connection %d (%d bytes) was garbage collected without Release, received at:\n%s",
+		leak.Connection, leak.Size, leak.Stack)
+}
+
+// trackMessage 在启用泄漏检测时为消息注册 finalizer
+func trackMessage(msg *Message) {
+	handler := leakHandler.Load()
+	if handler == nil {
+		return
+	}
+
+	msg.leakMeta = &messageLeakMeta{
+		stack:   string(debug.Stack()),
+		handler: *handler,
+	}
+	runtime.SetFinalizer(msg, finalizeMessage)
+}
+
+// finalizeMessage 是消息的 finalizer，报告泄漏并释放原生内存
+// 与 Release 相同，跨越 Shutdown 的消息不会调用原生函数
+func finalizeMessage(msg *Message) {
+	if msg.released.Load() {
+		return
+	}
+
+	msg.leakMeta.handler(MessageLeak{
+		Connection: msg.Connection,
+		Size:       len(msg.Data),
+		Stack:      msg.leakMeta.stack,
+	})
+	msg.Release()
+}
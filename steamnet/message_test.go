@@ -0,0 +1,145 @@
+package steamnet
+
+import (
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/guowei-gong/steamkit-go/internal/purego"
+)
+
+// stubReleaseNativeMessage 替换原生释放函数，返回每个指针的释放次数
+func stubReleaseNativeMessage(t *testing.T) func(ptr uintptr) int32 {
+	t.Helper()
+
+	var mu sync.Mutex
+	counts := make(map[uintptr]int32)
+	orig := releaseNativeMessage
+	releaseNativeMessage = func(ptr uintptr) {
+		mu.Lock()
+		counts[ptr]++
+		mu.Unlock()
+	}
+	t.Cleanup(func() { releaseNativeMessage = orig })
+
+	return func(ptr uintptr) int32 {
+		mu.Lock()
+		defer mu.Unlock()
+		return counts[ptr]
+	}
+}
+
+func TestMessage_ReleaseCallsNative(t *testing.T) {
+	count := stubReleaseNativeMessage(t)
+
+	msg := &Message{Data: []byte("test"), cPtr: 0x1000}
+	msg.Release()
+	msg.Release()
+	ReleaseMessage(msg)
+
+	if got := count(0x1000); got != 1 {
+		t.Errorf("native release called %d times, want 1", got)
+	}
+	if string(msg.Data) != "test" {
+		t.Errorf("Data after Release = %q, want %q", msg.Data, "test")
+	}
+}
+
+func TestMessage_ReleaseConcurrent(t *testing.T) {
+	count := stubReleaseNativeMessage(t)
+
+	msg := &Message{cPtr: 0x2000}
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			msg.Release()
+		}()
+	}
+	wg.Wait()
+
+	if got := count(0x2000); got != 1 {
+		t.Errorf("native release called %d times, want 1", got)
+	}
+}
+
+func TestMessage_ReleaseAfterShutdown(t *testing.T) {
+	count := stubReleaseNativeMessage(t)
+
+	// 在上一代接收的消息，原生内存已随 Shutdown 释放
+	msg := &Message{cPtr: 0x6000, generation: purego.Generation() + 1}
+	msg.Release()
+
+	if got := count(0x6000); got != 0 {
+		t.Errorf("native release called %d times for stale message, want 0", got)
+	}
+	if !msg.IsReleased() {
+		t.Error("stale message should be marked as released")
+	}
+}
+
+func TestMessage_ReleaseNil(t *testing.T) {
+	var msg *Message
+	msg.Release()
+	ReleaseMessage(nil)
+}
+
+func TestSetMessageLeakHandler(t *testing.T) {
+	count := stubReleaseNativeMessage(t)
+
+	leaks := make(chan MessageLeak, 1)
+	SetMessageLeakHandler(func(leak MessageLeak) { leaks <- leak })
+	t.Cleanup(func() { SetMessageLeakHandler(nil) })
+
+	// 已释放的消息不应被报告
+	released := &Message{cPtr: 0x3000}
+	trackMessage(released)
+	if released.leakMeta == nil {
+		t.Fatal("trackMessage() did not record leak metadata")
+	}
+	released.Release()
+
+	func() {
+		msg := &Message{Connection: Connection(7), Data: []byte("leak"), cPtr: 0x4000}
+		trackMessage(msg)
+	}()
+
+	var leak MessageLeak
+	deadline := time.After(5 * time.Second)
+	for received := false; !received; {
+		runtime.GC()
+		select {
+		case leak = <-leaks:
+			received = true
+		case <-deadline:
+			t.Fatal("leak handler was not called for unreleased message")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	if leak.Connection != Connection(7) || leak.Size != 4 {
+		t.Errorf("leak = {%d, %d}, want {7, 4}", leak.Connection, leak.Size)
+	}
+	if !strings.Contains(leak.Stack, "TestSetMessageLeakHandler") {
+		t.Errorf("leak stack does not include receiving function:\n%s", leak.Stack)
+	}
+	if got := count(0x4000); got != 1 {
+		t.Errorf("leaked message native release called %d times, want 1", got)
+	}
+	if got := count(0x3000); got != 1 {
+		t.Errorf("released message native release called %d times, want 1", got)
+	}
+}
+
+func TestTrackMessage_Disabled(t *testing.T) {
+	SetMessageLeakHandler(nil)
+
+	msg := &Message{cPtr: 0x5000}
+	trackMessage(msg)
+	if msg.leakMeta != nil {
+		t.Error("trackMessage() should not track messages when leak detection is disabled")
+	}
+}
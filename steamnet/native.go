@@ -0,0 +1,80 @@
+package steamnet
+
+import (
+	"errors"
+	"unsafe"
+
+	"github.com/guowei-gong/steamkit-go/internal/purego"
+)
+
+// nativeBytes 将原生内存视为字节切片（不复制）
+// 返回的切片只在原生内存有效期间可用
+func nativeBytes(ptr uintptr, size int) []byte {
+	if ptr == 0 || size <= 0 {
+		return nil
+	}
+	// 通过 *unsafe.Pointer 转换，避免直接将 uintptr 转为 unsafe.Pointer
+	return unsafe.Slice((*byte)(*(*unsafe.Pointer)(unsafe.Pointer(&ptr))), size)
+}
+
+// cString 从定长字符数组中读取以 0 结尾的字符串
+func cString(b []byte) string {
+	for i, c := range b {
+		if c == 0 {
+			return string(b[:i])
+		}
+	}
+	return string(b)
+}
+
+// flat API 符号名前缀
+const (
+	socketsSymbolPrefix = "SteamAPI_ISteamNetworkingSockets_"
+	utilsSymbolPrefix   = "SteamAPI_ISteamNetworkingUtils_"
+)
+
+// checkInitialized 在 Steam API 未初始化时返回 ErrNotInitialized
+func checkInitialized() error {
+	if purego.CheckInitialized() != nil {
+		return ErrNotInitialized
+	}
+	return nil
+}
+
+// checkGeneration 检查在 gen 代获取的接口句柄是否仍然有效
+// Shutdown 之后（即使已重新 Init）旧句柄都返回 ErrNotInitialized
+func checkGeneration(gen uint64) error {
+	if purego.CheckGeneration(gen) != nil {
+		return ErrNotInitialized
+	}
+	return nil
+}
+
+// requireSymbols 检查加载的库中是否存在所有指定的函数
+// 缺失时返回包装了 ErrUnsupported 的 *Error，Message 为缺失的符号
+func requireSymbols(names ...string) error {
+	err := purego.Require(names...)
+	var unsupported *purego.UnsupportedError
+	if errors.As(err, &unsupported) {
+		return &Error{Op: "require", Message: unsupported.Symbol, Err: ErrUnsupported}
+	}
+	return err
+}
+
+// requireSockets 检查 ISteamNetworkingSockets 方法是否可用
+func requireSockets(methods ...string) error {
+	names := make([]string, len(methods))
+	for i, m := range methods {
+		names[i] = socketsSymbolPrefix + m
+	}
+	return requireSymbols(names...)
+}
+
+// requireUtils 检查 ISteamNetworkingUtils 方法是否可用
+func requireUtils(methods ...string) error {
+	names := make([]string, len(methods))
+	for i, m := range methods {
+		names[i] = utilsSymbolPrefix + m
+	}
+	return requireSymbols(names...)
+}
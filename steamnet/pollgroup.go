@@ -0,0 +1,105 @@
+package steamnet
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/guowei-gong/steamkit-go/internal/purego"
+)
+
+// pollGroupManager 在 Go 侧跟踪轮询组及其成员连接
+type pollGroupManager struct {
+	mu          sync.RWMutex
+	groups      map[PollGroup]map[Connection]struct{}
+	connections map[Connection]PollGroup
+}
+
+var (
+	globalPollGroupManager = newPollGroupManager()
+)
+
+func init() {
+	// Shutdown 之后原生轮询组全部失效，句柄可能在下次初始化时被复用
+	purego.OnShutdown(globalPollGroupManager.reset)
+}
+
+// newPollGroupManager 创建轮询组管理器
+func newPollGroupManager() *pollGroupManager {
+	return &pollGroupManager{
+		groups:      make(map[PollGroup]map[Connection]struct{}),
+		connections: make(map[Connection]PollGroup),
+	}
+}
+
+// reset 清空所有轮询组和连接记录
+func (m *pollGroupManager) reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.groups = make(map[PollGroup]map[Connection]struct{})
+	m.connections = make(map[Connection]PollGroup)
+}
+
+// add 记录新创建的轮询组
+func (m *pollGroupManager) add(group PollGroup) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.groups[group] = make(map[Connection]struct{})
+}
+
+// remove 移除轮询组，组内连接不再属于任何轮询组
+func (m *pollGroupManager) remove(group PollGroup) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for conn := range m.groups[group] {
+		delete(m.connections, conn)
+	}
+	delete(m.groups, group)
+}
+
+// exists 检查轮询组是否存在（未被销毁）
+func (m *pollGroupManager) exists(group PollGroup) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	_, ok := m.groups[group]
+	return ok
+}
+
+// setConnection 将连接移入指定轮询组
+// group 为 InvalidPollGroup 时将连接移出当前轮询组
+func (m *pollGroupManager) setConnection(conn Connection, group PollGroup) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if old, ok := m.connections[conn]; ok {
+		delete(m.groups[old], conn)
+		delete(m.connections, conn)
+	}
+
+	if members, ok := m.groups[group]; ok {
+		members[conn] = struct{}{}
+		m.connections[conn] = group
+	}
+}
+
+// removeConnection 将连接从所属轮询组中移除（连接关闭时调用）
+func (m *pollGroupManager) removeConnection(conn Connection) {
+	m.setConnection(conn, InvalidPollGroup)
+}
+
+// connectionsOf 返回轮询组内的所有连接（按句柄排序）
+func (m *pollGroupManager) connectionsOf(group PollGroup) ([]Connection, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	members, ok := m.groups[group]
+	if !ok {
+		return nil, false
+	}
+
+	conns := make([]Connection, 0, len(members))
+	for conn := range members {
+		conns = append(conns, conn)
+	}
+	sort.Slice(conns, func(i, j int) bool { return conns[i] < conns[j] })
+	return conns, true
+}
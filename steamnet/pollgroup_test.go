@@ -0,0 +1,115 @@
+package steamnet
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPollGroupManager_Lifecycle(t *testing.T) {
+	m := newPollGroupManager()
+	group := PollGroup(1)
+
+	if m.exists(group) {
+		t.Fatal("exists() = true before add")
+	}
+
+	m.add(group)
+	if !m.exists(group) {
+		t.Fatal("exists() = false after add")
+	}
+
+	m.setConnection(Connection(3), group)
+	m.setConnection(Connection(2), group)
+
+	conns, ok := m.connectionsOf(group)
+	if !ok {
+		t.Fatal("connectionsOf() ok = false")
+	}
+	if want := []Connection{2, 3}; !reflect.DeepEqual(conns, want) {
+		t.Errorf("connectionsOf() = %v, want %v", conns, want)
+	}
+
+	m.remove(group)
+	if m.exists(group) {
+		t.Error("exists() = true after remove")
+	}
+	if _, ok := m.connectionsOf(group); ok {
+		t.Error("connectionsOf() ok = true after remove")
+	}
+	if len(m.connections) != 0 {
+		t.Errorf("connections = %v, want empty after remove", m.connections)
+	}
+}
+
+func TestPollGroupManager_MoveConnection(t *testing.T) {
+	m := newPollGroupManager()
+	group1 := PollGroup(1)
+	group2 := PollGroup(2)
+	conn := Connection(10)
+
+	m.add(group1)
+	m.add(group2)
+
+	m.setConnection(conn, group1)
+	m.setConnection(conn, group2)
+
+	conns1, _ := m.connectionsOf(group1)
+	conns2, _ := m.connectionsOf(group2)
+	if len(conns1) != 0 {
+		t.Errorf("group1 connections = %v, want empty", conns1)
+	}
+	if !reflect.DeepEqual(conns2, []Connection{conn}) {
+		t.Errorf("group2 connections = %v, want [%d]", conns2, conn)
+	}
+
+	// 移出轮询组
+	m.setConnection(conn, InvalidPollGroup)
+	conns2, _ = m.connectionsOf(group2)
+	if len(conns2) != 0 {
+		t.Errorf("group2 connections = %v, want empty", conns2)
+	}
+}
+
+func TestPollGroupManager_RemoveConnection(t *testing.T) {
+	m := newPollGroupManager()
+	group := PollGroup(1)
+	m.add(group)
+	m.setConnection(Connection(1), group)
+	m.setConnection(Connection(2), group)
+
+	m.removeConnection(Connection(1))
+
+	conns, _ := m.connectionsOf(group)
+	if !reflect.DeepEqual(conns, []Connection{2}) {
+		t.Errorf("connectionsOf() = %v, want [2]", conns)
+	}
+}
+
+func TestPollGroupManager_DestroyedGroup(t *testing.T) {
+	m := newPollGroupManager()
+	group := PollGroup(1)
+	m.add(group)
+	m.remove(group)
+
+	// 加入已销毁的轮询组不应被记录
+	m.setConnection(Connection(1), group)
+	if _, ok := m.connections[Connection(1)]; ok {
+		t.Error("connection should not be tracked in a destroyed group")
+	}
+}
+
+func TestPollGroupManager_Reset(t *testing.T) {
+	m := newPollGroupManager()
+	group := PollGroup(1)
+	m.add(group)
+	m.setConnection(Connection(1), group)
+
+	// Shutdown 之后旧的轮询组和成员都不再有效
+	m.reset()
+	if m.exists(group) {
+		t.Error("group should not exist after reset")
+	}
+	if _, ok := m.connections[Connection(1)]; ok {
+		t.Error("connection should not be tracked after reset")
+	}
+}
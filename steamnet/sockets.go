@@ -1,9 +1,11 @@
 package steamnet
 
 import (
+	"encoding/binary"
 	"fmt"
 	"unsafe"
 
+	"github.com/guowei-gong/steamkit-go"
 	"github.com/guowei-gong/steamkit-go/internal/purego"
 )
 
@@ -13,96 +15,239 @@ type ISteamNetworkingSockets interface {
 	CreateListenSocketP2P(virtualPort int, options []ConfigValue) (ListenSocket, error)
 	ConnectP2P(identity Identity, virtualPort int, options []ConfigValue) (Connection, error)
 	AcceptConnection(conn Connection) error
-	CloseConnection(conn Connection, reason int, debug string, linger bool) error
+	AcceptConnectionWithUserData(conn Connection, userData int64, name string) error
+	CloseConnection(conn Connection, reason ConnectionEndReason, debug string, linger bool) error
 	CloseListenSocket(socket ListenSocket) error
 
+	// IP 连接管理
+	CreateListenSocketIP(localAddr Identity, options []ConfigValue) (ListenSocket, error)
+	ConnectByIPAddress(addr Identity, options []ConfigValue) (Connection, error)
+
 	// 消息收发
 	SendMessageToConnection(conn Connection, data []byte, flags SendFlags) error
+	SendMessages(messages []OutgoingMessage) []SendResult
 	FlushMessagesOnConnection(conn Connection) error
 	ReceiveMessagesOnConnection(conn Connection, maxMessages int) ([]*Message, error)
 	ReceiveMessagesOnPollGroup(group PollGroup, maxMessages int) ([]*Message, error)
 
+	// 轮询组
+	CreatePollGroup() (PollGroup, error)
+	DestroyPollGroup(group PollGroup) error
+	SetConnectionPollGroup(conn Connection, group PollGroup) error
+	GetPollGroupConnections(group PollGroup) ([]Connection, error)
+
 	// 连接信息
 	GetConnectionInfo(conn Connection) (*ConnectionInfo, error)
+	SetConnectionUserData(conn Connection, userData int64) error
+	GetConnectionUserData(conn Connection) (int64, error)
+	SetConnectionName(conn Connection, name string) error
+	GetConnectionName(conn Connection) (string, error)
 	GetConnectionRealTimeStatus(conn Connection) (*QuickConnectionStatus, error)
+
+	// 通道（lane）
+	ConfigureConnectionLanes(conn Connection, lanes []LaneConfig) error
+
+	// 回调
+	RunCallbacks()
 }
 
 // steamNetworkingSockets 是 ISteamNetworkingSockets 的实现
 type steamNetworkingSockets struct {
-	handle uintptr
+	handle     uintptr
+	utils      uintptr // ISteamNetworkingUtils，用于 AllocateMessage
+	generation uint64  // 获取句柄时的生命周期代数，Shutdown 后句柄失效
 }
 
 // GetSockets 返回 ISteamNetworkingSockets 接口实例
-func GetSockets() ISteamNetworkingSockets {
+// 未初始化时返回 ErrNotInitialized；Shutdown 之后返回的实例失效，需要在重新 Init 后再次获取
+func GetSockets() (ISteamNetworkingSockets, error) {
+	if err := checkInitialized(); err != nil {
+		return nil, &Error{Op: "GetSockets", Err: err}
+	}
 	handle := purego.CallGetSteamNetworkingSockets()
 	if handle == 0 {
-		return nil
+		return nil, &Error{Op: "GetSockets", Message: "ISteamNetworkingSockets is not available", Err: ErrNotInitialized}
+	}
+	utils := purego.CallGetSteamNetworkingUtils()
+	// 注册连接状态变化回调，之后创建的连接都会继承此配置
+	installConnectionStatusChangedHook(utils)
+	// 让 steamkit.StartCallbackLoop 每帧分发网络回调，连接状态变化回调在循环中触发
+	if purego.Supported(socketsSymbolPrefix + "RunCallbacks") {
+		purego.SetFrameHook("steamnet.RunCallbacks", func() {
+			purego.CallRunCallbacksSockets(handle)
+		})
 	}
 	return &steamNetworkingSockets{
-		handle: handle,
+		handle:     handle,
+		utils:      utils,
+		generation: purego.Generation(),
+	}, nil
+}
+
+// require 检查句柄是否仍然有效以及 ISteamNetworkingSockets 方法是否可用
+// 返回的 *Error 以 methods[0] 作为 Op
+func (s *steamNetworkingSockets) require(methods ...string) error {
+	if err := checkGeneration(s.generation); err != nil {
+		return &Error{Op: methods[0], Err: err}
+	}
+	if err := requireSockets(methods...); err != nil {
+		return withOp(methods[0], err)
 	}
+	return nil
 }
 
 // CreateListenSocketP2P 创建一个 P2P 监听套接字
 func (s *steamNetworkingSockets) CreateListenSocketP2P(virtualPort int, options []ConfigValue) (ListenSocket, error) {
-	// TODO: 处理 options 参数
-	// 目前传递 0 和 nil
-	handle := purego.CallCreateListenSocketP2P(s.handle, int32(virtualPort), 0, 0)
+	if err := s.require("CreateListenSocketP2P"); err != nil {
+		return InvalidListenSocket, err
+	}
+
+	opts, err := marshalConfigValues(options)
+	if err != nil {
+		return InvalidListenSocket, withOp("CreateListenSocketP2P", err)
+	}
+
+	handle := purego.CallCreateListenSocketP2P(s.handle, int32(virtualPort), opts.count(), opts.ptr())
+	opts.keepAlive()
 	if handle == 0 {
-		return InvalidListenSocket, ErrInvalidSocket
+		return InvalidListenSocket, &Error{Op: "CreateListenSocketP2P", Err: ErrInvalidSocket}
 	}
 	return ListenSocket(handle), nil
 }
 
 // ConnectP2P 连接到远程 P2P 对等方
 func (s *steamNetworkingSockets) ConnectP2P(identity Identity, virtualPort int, options []ConfigValue) (Connection, error) {
+	if err := s.require("ConnectP2P"); err != nil {
+		return InvalidConnection, err
+	}
+
 	if !identity.IsValid() {
-		return InvalidConnection, ErrInvalidIdentity
+		return InvalidConnection, &Error{Op: "ConnectP2P", Identity: identity, Err: ErrInvalidIdentity}
 	}
 
-	// 目前仅支持 SteamID
-	if identity.Type() != IdentityTypeSteamID {
-		return InvalidConnection, fmt.Errorf("only SteamID identity is supported currently")
+	identityStruct, err := marshalIdentity(identity)
+	if err != nil {
+		e := withOp("ConnectP2P", err)
+		e.Identity = identity
+		return InvalidConnection, e
 	}
 
-	// 创建 SteamNetworkingIdentity 结构体
-	// 结构体布局:
-	//   offset 0:   m_eType (int32, 4 bytes)
-	//   offset 4:   m_cbSize (int32, 4 bytes)
-	//   offset 8:   union (128 bytes, 包含 m_steamID64)
-	// 总大小: 136 bytes
-	var identityStruct [136]byte
+	opts, err := marshalConfigValues(options)
+	if err != nil {
+		e := withOp("ConnectP2P", err)
+		e.Identity = identity
+		return InvalidConnection, e
+	}
 
-	// 使用 Steam API 辅助函数正确初始化结构体
-	identityPtr := uintptr(unsafe.Pointer(&identityStruct[0]))
-	purego.CallSteamNetworkingIdentityClear(identityPtr)
-	purego.CallSteamNetworkingIdentitySetSteamID64(identityPtr, identity.GetSteamID())
+	handle := purego.CallConnectP2P(s.handle, uintptr(unsafe.Pointer(&identityStruct[0])), int32(virtualPort), opts.count(), opts.ptr())
+	opts.keepAlive()
+	if handle == 0 {
+		return InvalidConnection, &Error{Op: "ConnectP2P", Identity: identity, Err: ErrConnectionFailed}
+	}
+	return Connection(handle), nil
+}
+
+// CreateListenSocketIP 创建一个 IP 监听套接字
+// localAddr 必须是 IP 类型的身份，IP 为空表示监听所有本地地址
+func (s *steamNetworkingSockets) CreateListenSocketIP(localAddr Identity, options []ConfigValue) (ListenSocket, error) {
+	if err := s.require("CreateListenSocketIP"); err != nil {
+		return InvalidListenSocket, err
+	}
 
-	// TODO: 处理 options 参数
-	handle := purego.CallConnectP2P(s.handle, identityPtr, int32(virtualPort), 0, 0)
+	addr, err := marshalIdentityIPAddr(localAddr)
+	if err != nil {
+		e := withOp("CreateListenSocketIP", err)
+		e.Identity = localAddr
+		return InvalidListenSocket, e
+	}
+
+	opts, err := marshalConfigValues(options)
+	if err != nil {
+		e := withOp("CreateListenSocketIP", err)
+		e.Identity = localAddr
+		return InvalidListenSocket, e
+	}
+
+	handle := purego.CallCreateListenSocketIP(s.handle, uintptr(unsafe.Pointer(&addr[0])), opts.count(), opts.ptr())
+	opts.keepAlive()
 	if handle == 0 {
-		return InvalidConnection, ErrConnectionFailed
+		return InvalidListenSocket, &Error{Op: "CreateListenSocketIP", Identity: localAddr, Err: ErrInvalidSocket}
+	}
+	return ListenSocket(handle), nil
+}
+
+// ConnectByIPAddress 通过 IP 地址连接到远程主机
+// addr 必须是 IP 类型的身份，支持 IPv4 和 IPv6
+func (s *steamNetworkingSockets) ConnectByIPAddress(addr Identity, options []ConfigValue) (Connection, error) {
+	if err := s.require("ConnectByIPAddress"); err != nil {
+		return InvalidConnection, err
+	}
+
+	if !addr.IsValid() {
+		return InvalidConnection, &Error{Op: "ConnectByIPAddress", Identity: addr, Err: ErrInvalidIdentity}
+	}
+
+	ipAddr, err := marshalIdentityIPAddr(addr)
+	if err != nil {
+		e := withOp("ConnectByIPAddress", err)
+		e.Identity = addr
+		return InvalidConnection, e
+	}
+
+	opts, err := marshalConfigValues(options)
+	if err != nil {
+		e := withOp("ConnectByIPAddress", err)
+		e.Identity = addr
+		return InvalidConnection, e
+	}
+
+	handle := purego.CallConnectByIPAddress(s.handle, uintptr(unsafe.Pointer(&ipAddr[0])), opts.count(), opts.ptr())
+	opts.keepAlive()
+	if handle == 0 {
+		return InvalidConnection, &Error{Op: "ConnectByIPAddress", Identity: addr, Err: ErrConnectionFailed}
 	}
 	return Connection(handle), nil
 }
 
 // AcceptConnection 接受传入连接
 func (s *steamNetworkingSockets) AcceptConnection(conn Connection) error {
+	if err := s.require("AcceptConnection"); err != nil {
+		return err
+	}
+
 	if conn == InvalidConnection {
-		return ErrInvalidConnection
+		return &Error{Op: "AcceptConnection", Connection: conn, Err: ErrInvalidConnection}
 	}
 
-	result := purego.CallAcceptConnection(s.handle, uint32(conn))
-	if result != 0 { // k_EResultOK = 1, 但这里返回 0 表示成功
-		return fmt.Errorf("failed to accept connection: result=%d", result)
+	result := steamkit.EResult(purego.CallAcceptConnection(s.handle, uint32(conn)))
+	return resultError("AcceptConnection", conn, result, ErrConnectionFailed)
+}
+
+// AcceptConnectionWithUserData 设置用户数据和名称后接受传入连接
+// 在接受之前设置用户数据，可以保证此后收到的每条消息的 Message.UserData 都已就绪
+// name 为空时不设置名称
+func (s *steamNetworkingSockets) AcceptConnectionWithUserData(conn Connection, userData int64, name string) error {
+	if err := s.SetConnectionUserData(conn, userData); err != nil {
+		return err
 	}
-	return nil
+	if name != "" {
+		if err := s.SetConnectionName(conn, name); err != nil {
+			return err
+		}
+	}
+	return s.AcceptConnection(conn)
 }
 
 // CloseConnection 关闭连接
-func (s *steamNetworkingSockets) CloseConnection(conn Connection, reason int, debug string, linger bool) error {
+// reason 通常为 EndReasonAppGeneric 或 AppEndReason / AppExceptionEndReason 定义的子码，
+// 对方在连接状态变化回调的 EndReason 中收到
+func (s *steamNetworkingSockets) CloseConnection(conn Connection, reason ConnectionEndReason, debug string, linger bool) error {
+	if err := s.require("CloseConnection"); err != nil {
+		return err
+	}
+
 	if conn == InvalidConnection {
-		return ErrInvalidConnection
+		return &Error{Op: "CloseConnection", Connection: conn, Err: ErrInvalidConnection}
 	}
 
 	// 将 debug 字符串转换为 C 字符串
@@ -114,101 +259,314 @@ func (s *steamNetworkingSockets) CloseConnection(conn Connection, reason int, de
 
 	success := purego.CallCloseConnection(s.handle, uint32(conn), int32(reason), debugPtr, linger)
 	if !success {
-		return fmt.Errorf("failed to close connection")
+		return &Error{Op: "CloseConnection", Connection: conn, Err: ErrInvalidConnection}
 	}
+
+	globalPollGroupManager.removeConnection(conn)
+	globalLaneManager.remove(conn)
 	return nil
 }
 
 // CloseListenSocket 关闭监听套接字
 func (s *steamNetworkingSockets) CloseListenSocket(socket ListenSocket) error {
+	if err := s.require("CloseListenSocket"); err != nil {
+		return err
+	}
+
 	if socket == InvalidListenSocket {
-		return ErrInvalidSocket
+		return &Error{Op: "CloseListenSocket", ListenSocket: socket, Err: ErrInvalidSocket}
 	}
 
 	success := purego.CallCloseListenSocket(s.handle, uint32(socket))
 	if !success {
-		return fmt.Errorf("failed to close listen socket")
+		return &Error{Op: "CloseListenSocket", ListenSocket: socket, Err: ErrInvalidSocket}
 	}
 	return nil
 }
 
 // GetConnectionInfo 获取连接信息
 func (s *steamNetworkingSockets) GetConnectionInfo(conn Connection) (*ConnectionInfo, error) {
+	if err := s.require("GetConnectionInfo"); err != nil {
+		return nil, err
+	}
+
 	if conn == InvalidConnection {
-		return nil, ErrInvalidConnection
+		return nil, &Error{Op: "GetConnectionInfo", Connection: conn, Err: ErrInvalidConnection}
 	}
 
-	// TODO: 定义完整的 SteamNetConnectionInfo_t 结构体
-	// 目前返回一个简化版本
-	var infoStruct [696]byte // SteamNetConnectionInfo_t 的大小（近似）
+	var infoStruct [connectionInfoSize]byte
 
 	success := purego.CallGetConnectionInfo(s.handle, uint32(conn), uintptr(unsafe.Pointer(&infoStruct[0])))
 	if !success {
-		return nil, fmt.Errorf("failed to get connection info")
+		return nil, &Error{Op: "GetConnectionInfo", Connection: conn, Err: ErrInvalidConnection}
 	}
 
-	// TODO: 解析结构体并填充 ConnectionInfo
-	// 目前返回一个空的 ConnectionInfo
+	return parseConnectionInfo(infoStruct[:]), nil
+}
+
+// connectionInfoSize 是 SteamNetConnectionInfo_t 的大小
+const connectionInfoSize = 696
+
+// parseConnectionInfo 解析 SteamNetConnectionInfo_t 结构体
+func parseConnectionInfo(b []byte) *ConnectionInfo {
+	// SteamNetConnectionInfo_t 结构体布局：
+	// offset 0:   SteamNetworkingIdentity m_identityRemote (136 bytes)
+	// offset 136: int64 m_nUserData
+	// offset 144: HSteamListenSocket m_hListenSocket (uint32)
+	// offset 148: SteamNetworkingIPAddr m_addrRemote (18 bytes)
+	// offset 166: uint16 m__pad1
+	// offset 168: SteamNetworkingPOPID m_idPOPRemote (uint32)
+	// offset 172: SteamNetworkingPOPID m_idPOPRelay (uint32)
+	// offset 176: int m_eState
+	// offset 180: int m_eEndReason
+	// offset 184: char m_szEndDebug[128]
+	// offset 312: char m_szConnectionDescription[128]
+	// offset 440: int m_nFlags
+	// offset 444: uint32 reserved[63]
+
 	info := &ConnectionInfo{
-		Identity: NewInvalidIdentity(),
-		State:    ConnectionState(*(*int32)(unsafe.Pointer(&infoStruct[0]))),
+		Identity:     unmarshalIdentity(b[0:identitySize]),
+		UserData:     int64(binary.LittleEndian.Uint64(b[136:])),
+		ListenSocket: ListenSocket(binary.LittleEndian.Uint32(b[144:])),
+		RemoteAddr:   decodeIPAddr(b[148 : 148+ipAddrSize]),
+		POPRemote:    POPID(binary.LittleEndian.Uint32(b[168:])),
+		POPRelay:     POPID(binary.LittleEndian.Uint32(b[172:])),
+		State:        ConnectionState(int32(binary.LittleEndian.Uint32(b[176:]))),
+		EndReason:    ConnectionEndReason(int32(binary.LittleEndian.Uint32(b[180:]))),
+		EndDebug:     cString(b[184:312]),
+		Description:  cString(b[312:440]),
+		Flags:        ConnectionInfoFlags(int32(binary.LittleEndian.Uint32(b[440:]))),
+	}
+
+	return info
+}
+
+// SetConnectionUserData 设置连接的用户数据
+// 用户数据会出现在该连接的 ConnectionInfo、状态变化回调和每条接收到的消息中
+func (s *steamNetworkingSockets) SetConnectionUserData(conn Connection, userData int64) error {
+	if err := s.require("SetConnectionUserData"); err != nil {
+		return err
+	}
+
+	if conn == InvalidConnection {
+		return &Error{Op: "SetConnectionUserData", Connection: conn, Err: ErrInvalidConnection}
+	}
+
+	if !purego.CallSetConnectionUserData(s.handle, uint32(conn), userData) {
+		return &Error{Op: "SetConnectionUserData", Connection: conn, Err: ErrInvalidConnection}
+	}
+	return nil
+}
+
+// GetConnectionUserData 获取连接的用户数据
+// 出错时（包括句柄失效和方法不可用）总是返回 -1，与原生层的无效值一致
+func (s *steamNetworkingSockets) GetConnectionUserData(conn Connection) (int64, error) {
+	if err := s.require("GetConnectionUserData"); err != nil {
+		return -1, err
+	}
+
+	if conn == InvalidConnection {
+		return -1, &Error{Op: "GetConnectionUserData", Connection: conn, Err: ErrInvalidConnection}
 	}
 
-	return info, nil
+	// 原生层对无效句柄返回 -1，但 -1 也可能是合法的用户数据，
+	// 因此通过 GetConnectionInfo 区分
+	userData := purego.CallGetConnectionUserData(s.handle, uint32(conn))
+	if userData == -1 {
+		if _, err := s.GetConnectionInfo(conn); err != nil {
+			return -1, &Error{Op: "GetConnectionUserData", Connection: conn, Err: ErrInvalidConnection}
+		}
+	}
+	return userData, nil
+}
+
+// connectionNameSize 是连接名称缓冲区的大小
+const connectionNameSize = 128
+
+// SetConnectionName 设置连接名称，用于原生调试输出
+func (s *steamNetworkingSockets) SetConnectionName(conn Connection, name string) error {
+	if err := s.require("SetConnectionName"); err != nil {
+		return err
+	}
+
+	if conn == InvalidConnection {
+		return &Error{Op: "SetConnectionName", Connection: conn, Err: ErrInvalidConnection}
+	}
+
+	purego.CallSetConnectionName(s.handle, uint32(conn), name)
+	return nil
+}
+
+// GetConnectionName 获取连接名称
+func (s *steamNetworkingSockets) GetConnectionName(conn Connection) (string, error) {
+	if err := s.require("GetConnectionName"); err != nil {
+		return "", err
+	}
+
+	if conn == InvalidConnection {
+		return "", &Error{Op: "GetConnectionName", Connection: conn, Err: ErrInvalidConnection}
+	}
+
+	var name [connectionNameSize]byte
+	if !purego.CallGetConnectionName(s.handle, uint32(conn), uintptr(unsafe.Pointer(&name[0])), int32(len(name))) {
+		return "", &Error{Op: "GetConnectionName", Connection: conn, Err: ErrInvalidConnection}
+	}
+	return cString(name[:]), nil
 }
 
 // SendMessageToConnection 发送消息到连接
 func (s *steamNetworkingSockets) SendMessageToConnection(conn Connection, data []byte, flags SendFlags) error {
+	if err := s.require("SendMessageToConnection"); err != nil {
+		return err
+	}
+
 	if conn == InvalidConnection {
-		return ErrInvalidConnection
+		return &Error{Op: "SendMessageToConnection", Connection: conn, Err: ErrInvalidConnection}
 	}
 
-	if len(data) == 0 {
-		return fmt.Errorf("data is empty")
+	// 允许发送空消息
+	var dataPtr uintptr
+	if len(data) > 0 {
+		dataPtr = uintptr(unsafe.Pointer(&data[0]))
 	}
 
 	// 调用 Steam API
 	result := purego.CallSendMessageToConnection(
 		s.handle,
 		uint32(conn),
-		uintptr(unsafe.Pointer(&data[0])),
+		dataPtr,
 		uint32(len(data)),
 		int32(flags),
 		0, // outMessageNumber (可选)
 	)
 
-	// k_EResultOK = 1
-	if result != 1 {
-		return fmt.Errorf("failed to send message: result=%d", result)
+	return resultError("SendMessageToConnection", conn, steamkit.EResult(result), ErrSendFailed)
+}
+
+// SendMessages 批量发送消息
+// 所有消息通过一次原生调用发送，返回值与 messages 一一对应
+func (s *steamNetworkingSockets) SendMessages(messages []OutgoingMessage) []SendResult {
+	results := make([]SendResult, len(messages))
+	if len(messages) == 0 {
+		return results
 	}
 
-	return nil
+	if err := checkGeneration(s.generation); err != nil {
+		for i := range results {
+			results[i].Err = &Error{Op: "SendMessages", Connection: messages[i].Connection, Err: err}
+		}
+		return results
+	}
+
+	if s.utils == 0 {
+		for i := range results {
+			results[i].Err = &Error{Op: "SendMessages", Connection: messages[i].Connection, Message: "ISteamNetworkingUtils is not available", Err: ErrSendFailed}
+		}
+		return results
+	}
+
+	if err := requireSymbols(socketsSymbolPrefix+"SendMessages", utilsSymbolPrefix+"AllocateMessage"); err != nil {
+		for i := range results {
+			e := withOp("SendMessages", err)
+			e.Connection = messages[i].Connection
+			results[i].Err = e
+		}
+		return results
+	}
+
+	// 分配原生消息并填充字段
+	msgPtrs := make([]uintptr, 0, len(messages))
+	indices := make([]int, 0, len(messages))
+	for i, msg := range messages {
+		if err := msg.validate(); err != nil {
+			e := withOp("SendMessages", err)
+			e.Connection = msg.Connection
+			results[i].Err = e
+			continue
+		}
+
+		// 未配置通道的连接只有 0 号通道
+		if numLanes := globalLaneManager.get(msg.Connection); msg.Lane > 0 && msg.Lane >= numLanes {
+			results[i].Err = &Error{Op: "SendMessages", Connection: msg.Connection, Message: fmt.Sprintf("lane %d is not configured", msg.Lane), Err: ErrInvalidMessage}
+			continue
+		}
+
+		msgPtr := purego.CallAllocateMessage(s.utils, int32(len(msg.Data)))
+		if msgPtr == 0 {
+			results[i].Err = &Error{Op: "SendMessages", Connection: msg.Connection, Message: "failed to allocate message", Err: ErrSendFailed}
+			continue
+		}
+
+		b := nativeBytes(msgPtr, messageSize)
+		dataPtr := uintptr(binary.LittleEndian.Uint64(b[0:]))
+		copy(nativeBytes(dataPtr, len(msg.Data)), msg.Data)
+		encodeOutgoingMessage(b, msg)
+
+		msgPtrs = append(msgPtrs, msgPtr)
+		indices = append(indices, i)
+	}
+
+	if len(msgPtrs) == 0 {
+		return results
+	}
+
+	// SendMessages 接管所有消息的所有权，无论发送是否成功
+	out := make([]int64, len(msgPtrs))
+	purego.CallSendMessages(
+		s.handle,
+		int32(len(msgPtrs)),
+		uintptr(unsafe.Pointer(&msgPtrs[0])),
+		uintptr(unsafe.Pointer(&out[0])),
+	)
+
+	// 正数为消息序号，负数为 -EResult
+	for j, i := range indices {
+		if out[j] >= 0 {
+			results[i].MessageNumber = out[j]
+		} else {
+			results[i].Err = resultError("SendMessages", messages[i].Connection, steamkit.EResult(-out[j]), ErrSendFailed)
+		}
+	}
+
+	return results
+}
+
+// encodeOutgoingMessage 将待发送消息的字段写入 SteamNetworkingMessage_t
+// 消息数据由调用方写入 m_pData 指向的缓冲区
+func encodeOutgoingMessage(b []byte, msg OutgoingMessage) {
+	binary.LittleEndian.PutUint32(b[12:], uint32(msg.Connection))
+	binary.LittleEndian.PutUint32(b[196:], uint32(int32(msg.Flags)))
+	binary.LittleEndian.PutUint64(b[200:], uint64(msg.UserData))
+	binary.LittleEndian.PutUint16(b[208:], uint16(msg.Lane))
 }
 
 // FlushMessagesOnConnection 刷新连接上的消息
 func (s *steamNetworkingSockets) FlushMessagesOnConnection(conn Connection) error {
+	if err := s.require("FlushMessagesOnConnection"); err != nil {
+		return err
+	}
+
 	if conn == InvalidConnection {
-		return ErrInvalidConnection
+		return &Error{Op: "FlushMessagesOnConnection", Connection: conn, Err: ErrInvalidConnection}
 	}
 
 	result := purego.CallFlushMessagesOnConnection(s.handle, uint32(conn))
 
-	// k_EResultOK = 1
-	if result != 1 {
-		return fmt.Errorf("failed to flush messages: result=%d", result)
-	}
-
-	return nil
+	return resultError("FlushMessagesOnConnection", conn, steamkit.EResult(result), ErrSendFailed)
 }
 
 // ReceiveMessagesOnConnection 接收连接上的消息
 func (s *steamNetworkingSockets) ReceiveMessagesOnConnection(conn Connection, maxMessages int) ([]*Message, error) {
+	if err := s.require("ReceiveMessagesOnConnection"); err != nil {
+		return nil, err
+	}
+
 	if conn == InvalidConnection {
-		return nil, ErrInvalidConnection
+		return nil, &Error{Op: "ReceiveMessagesOnConnection", Connection: conn, Err: ErrInvalidConnection}
 	}
 
 	if maxMessages <= 0 {
-		return nil, fmt.Errorf("maxMessages must be positive")
+		return nil, &Error{Op: "ReceiveMessagesOnConnection", Connection: conn, Message: "maxMessages must be positive", Err: ErrReceiveFailed}
 	}
 
 	// 创建消息指针数组
@@ -223,7 +581,7 @@ func (s *steamNetworkingSockets) ReceiveMessagesOnConnection(conn Connection, ma
 	)
 
 	if numMessages < 0 {
-		return nil, fmt.Errorf("failed to receive messages: result=%d", numMessages)
+		return nil, &Error{Op: "ReceiveMessagesOnConnection", Connection: conn, Err: ErrInvalidConnection}
 	}
 
 	if numMessages == 0 {
@@ -249,12 +607,16 @@ func (s *steamNetworkingSockets) ReceiveMessagesOnConnection(conn Connection, ma
 
 // ReceiveMessagesOnPollGroup 接收轮询组上的消息
 func (s *steamNetworkingSockets) ReceiveMessagesOnPollGroup(group PollGroup, maxMessages int) ([]*Message, error) {
-	if group == InvalidPollGroup {
-		return nil, ErrInvalidPollGroup
+	if err := s.require("ReceiveMessagesOnPollGroup"); err != nil {
+		return nil, err
+	}
+
+	if group == InvalidPollGroup || !globalPollGroupManager.exists(group) {
+		return nil, &Error{Op: "ReceiveMessagesOnPollGroup", Err: ErrInvalidPollGroup}
 	}
 
 	if maxMessages <= 0 {
-		return nil, fmt.Errorf("maxMessages must be positive")
+		return nil, &Error{Op: "ReceiveMessagesOnPollGroup", Message: "maxMessages must be positive", Err: ErrReceiveFailed}
 	}
 
 	// 创建消息指针数组
@@ -269,7 +631,7 @@ func (s *steamNetworkingSockets) ReceiveMessagesOnPollGroup(group PollGroup, max
 	)
 
 	if numMessages < 0 {
-		return nil, fmt.Errorf("failed to receive messages: result=%d", numMessages)
+		return nil, &Error{Op: "ReceiveMessagesOnPollGroup", Err: ErrInvalidPollGroup}
 	}
 
 	if numMessages == 0 {
@@ -292,76 +654,171 @@ func (s *steamNetworkingSockets) ReceiveMessagesOnPollGroup(group PollGroup, max
 	return messages, nil
 }
 
-// parseMessage 解析 SteamNetworkingMessage_t 结构体
-func parseMessage(msgPtr uintptr, conn Connection) *Message {
-	// SteamNetworkingMessage_t 结构体布局（简化）：
-	// offset 0: void* m_pData
-	// offset 8: int m_cbSize
-	// offset 16: HSteamNetConnection m_conn
-	// offset 24: SteamNetworkingIdentity m_identityPeer
-	// offset 160: int64 m_nConnUserData
-	// offset 168: SteamNetworkingMicroseconds m_usecTimeReceived
-
-	dataPtr := *(*uintptr)(unsafe.Pointer(msgPtr))
-	dataSize := *(*int32)(unsafe.Pointer(msgPtr + 8))
-	msgConn := *(*uint32)(unsafe.Pointer(msgPtr + 16))
-	timeReceived := *(*int64)(unsafe.Pointer(msgPtr + 168))
+// messageSize 是 SteamNetworkingMessage_t 中需要读取的字节数（到 m_idxLane 为止）
+const messageSize = 212
 
-	// 复制数据到 Go 切片
-	data := make([]byte, dataSize)
-	if dataSize > 0 && dataPtr != 0 {
-		src := unsafe.Slice((*byte)(unsafe.Pointer(dataPtr)), dataSize)
-		copy(data, src)
+// CreatePollGroup 创建轮询组
+func (s *steamNetworkingSockets) CreatePollGroup() (PollGroup, error) {
+	if err := s.require("CreatePollGroup"); err != nil {
+		return InvalidPollGroup, err
+	}
+
+	handle := purego.CallCreatePollGroup(s.handle)
+	if handle == 0 {
+		return InvalidPollGroup, &Error{Op: "CreatePollGroup", Err: ErrInvalidPollGroup}
+	}
+
+	group := PollGroup(handle)
+	globalPollGroupManager.add(group)
+	return group, nil
+}
+
+// DestroyPollGroup 销毁轮询组
+// 组内的连接不会被关闭，但会离开该轮询组
+func (s *steamNetworkingSockets) DestroyPollGroup(group PollGroup) error {
+	if err := s.require("DestroyPollGroup"); err != nil {
+		return err
+	}
+
+	if group == InvalidPollGroup || !globalPollGroupManager.exists(group) {
+		return &Error{Op: "DestroyPollGroup", Err: ErrInvalidPollGroup}
+	}
+
+	// 无论原生调用是否成功，该句柄都不应再被使用
+	success := purego.CallDestroyPollGroup(s.handle, uint32(group))
+	globalPollGroupManager.remove(group)
+	if !success {
+		return &Error{Op: "DestroyPollGroup", Err: ErrInvalidPollGroup}
+	}
+	return nil
+}
+
+// SetConnectionPollGroup 将连接加入轮询组
+// group 为 InvalidPollGroup 时将连接移出当前轮询组
+func (s *steamNetworkingSockets) SetConnectionPollGroup(conn Connection, group PollGroup) error {
+	if err := s.require("SetConnectionPollGroup"); err != nil {
+		return err
 	}
 
-	// 如果连接无效，使用消息中的连接
 	if conn == InvalidConnection {
-		conn = Connection(msgConn)
+		return &Error{Op: "SetConnectionPollGroup", Connection: conn, Err: ErrInvalidConnection}
 	}
 
-	return &Message{
-		Data:         data,
-		Connection:   conn,
-		Identity:     NewInvalidIdentity(), // TODO: 解析 m_identityPeer
-		UserData:     0,                     // TODO: 解析 m_nConnUserData
-		TimeReceived: timeReceived,
-		cPtr:         msgPtr,
-		released:     false,
+	if group != InvalidPollGroup && !globalPollGroupManager.exists(group) {
+		return &Error{Op: "SetConnectionPollGroup", Connection: conn, Err: ErrInvalidPollGroup}
 	}
+
+	success := purego.CallSetConnectionPollGroup(s.handle, uint32(conn), uint32(group))
+	if !success {
+		return &Error{Op: "SetConnectionPollGroup", Connection: conn, Err: ErrInvalidConnection}
+	}
+
+	globalPollGroupManager.setConnection(conn, group)
+	return nil
 }
 
-// ReleaseMessage 释放消息内存（辅助函数）
-func ReleaseMessage(msg *Message) {
-	if msg == nil || msg.released {
-		return
+// GetPollGroupConnections 返回轮询组内的所有连接
+// 只读取 Go 侧记录，不调用原生函数，因此只检查句柄是否仍然有效
+func (s *steamNetworkingSockets) GetPollGroupConnections(group PollGroup) ([]Connection, error) {
+	if err := checkGeneration(s.generation); err != nil {
+		return nil, &Error{Op: "GetPollGroupConnections", Err: err}
 	}
-	if msg.cPtr != 0 {
-		purego.CallReleaseMessage(msg.cPtr)
+	conns, ok := globalPollGroupManager.connectionsOf(group)
+	if !ok {
+		return nil, &Error{Op: "GetPollGroupConnections", Err: ErrInvalidPollGroup}
+	}
+	return conns, nil
+}
+
+// parseMessage 解析 SteamNetworkingMessage_t 结构体
+func parseMessage(msgPtr uintptr, conn Connection) *Message {
+	msg := decodeMessage(nativeBytes(msgPtr, messageSize))
+
+	// 如果指定了连接，优先使用调用方的连接
+	if conn != InvalidConnection {
+		msg.Connection = conn
+	}
+	msg.cPtr = msgPtr
+	msg.generation = purego.Generation()
+	trackMessage(msg)
+
+	return msg
+}
+
+// decodeMessage 解码 SteamNetworkingMessage_t 结构体并复制消息数据
+func decodeMessage(b []byte) *Message {
+	// SteamNetworkingMessage_t 结构体布局：
+	// offset 0:   void* m_pData
+	// offset 8:   int m_cbSize
+	// offset 12:  HSteamNetConnection m_conn
+	// offset 16:  SteamNetworkingIdentity m_identityPeer (136 bytes)
+	// offset 152: int64 m_nConnUserData
+	// offset 160: SteamNetworkingMicroseconds m_usecTimeReceived
+	// offset 168: int64 m_nMessageNumber
+	// offset 176: void (*m_pfnFreeData)(SteamNetworkingMessage_t*)
+	// offset 184: void (*m_pfnRelease)(SteamNetworkingMessage_t*)
+	// offset 192: int m_nChannel
+	// offset 196: int m_nFlags
+	// offset 200: int64 m_nUserData
+	// offset 208: uint16 m_idxLane
+
+	dataPtr := uintptr(binary.LittleEndian.Uint64(b[0:]))
+	dataSize := int(int32(binary.LittleEndian.Uint32(b[8:])))
+
+	// 复制数据到 Go 切片
+	var data []byte
+	if dataSize > 0 {
+		data = make([]byte, dataSize)
+		copy(data, nativeBytes(dataPtr, dataSize))
+	} else {
+		data = []byte{}
+	}
+
+	return &Message{
+		Data:          data,
+		Connection:    Connection(binary.LittleEndian.Uint32(b[12:])),
+		Identity:      unmarshalIdentity(b[16 : 16+identitySize]),
+		UserData:      int64(binary.LittleEndian.Uint64(b[152:])),
+		TimeReceived:  int64(binary.LittleEndian.Uint64(b[160:])),
+		MessageNumber: int64(binary.LittleEndian.Uint64(b[168:])),
+		Channel:       int(int32(binary.LittleEndian.Uint32(b[192:]))),
+		Flags:         SendFlags(int32(binary.LittleEndian.Uint32(b[196:]))),
+		Lane:          int(binary.LittleEndian.Uint16(b[208:])),
 	}
-	msg.released = true
 }
 
 // GetConnectionRealTimeStatus 获取连接的实时状态
 func (s *steamNetworkingSockets) GetConnectionRealTimeStatus(conn Connection) (*QuickConnectionStatus, error) {
+	if err := s.require("GetConnectionRealTimeStatus"); err != nil {
+		return nil, err
+	}
+
 	if conn == InvalidConnection {
-		return nil, ErrInvalidConnection
+		return nil, &Error{Op: "GetConnectionRealTimeStatus", Connection: conn, Err: ErrInvalidConnection}
 	}
 
-	// SteamNetConnectionRealTimeStatus_t 结构体（简化版本）
-	// 实际结构体大小约为 312 字节
-	var statusStruct [312]byte
+	// SteamNetConnectionRealTimeStatus_t 结构体（120 字节）
+	var statusStruct [120]byte
+
+	// 已配置通道的连接同时获取每个通道的状态
+	numLanes := globalLaneManager.get(conn)
+	var laneStructs []byte
+	var lanesPtr uintptr
+	if numLanes > 0 {
+		laneStructs = make([]byte, numLanes*laneStatusSize)
+		lanesPtr = uintptr(unsafe.Pointer(&laneStructs[0]))
+	}
 
 	result := purego.CallGetConnectionRealTimeStatus(
 		s.handle,
 		uint32(conn),
 		uintptr(unsafe.Pointer(&statusStruct[0])),
-		0, // numLanes
-		0, // lanes
+		int32(numLanes),
+		lanesPtr,
 	)
 
-	// k_EResultOK = 1
-	if result != 1 {
-		return nil, fmt.Errorf("failed to get connection real-time status: result=%d", result)
+	if err := resultError("GetConnectionRealTimeStatus", conn, steamkit.EResult(result), nil); err != nil {
+		return nil, err
 	}
 
 	// 解析 SteamNetConnectionRealTimeStatus_t 结构体
@@ -377,6 +834,7 @@ func (s *steamNetworkingSockets) GetConnectionRealTimeStatus(conn Connection) (*
 	// offset 36: int m_cbPendingUnreliable (int32)
 	// offset 40: int m_cbPendingReliable (int32)
 	// offset 44: int m_cbSentUnackedReliable (int32)
+	// offset 48: SteamNetworkingMicroseconds m_usecQueueTime (int64)
 
 	status := &QuickConnectionStatus{
 		State:               ConnectionState(*(*int32)(unsafe.Pointer(&statusStruct[0]))),
@@ -390,7 +848,57 @@ func (s *steamNetworkingSockets) GetConnectionRealTimeStatus(conn Connection) (*
 		PendingUnreliable:   int(*(*int32)(unsafe.Pointer(&statusStruct[36]))),
 		PendingReliable:     int(*(*int32)(unsafe.Pointer(&statusStruct[40]))),
 		SentUnackedReliable: int(*(*int32)(unsafe.Pointer(&statusStruct[44]))),
+		QueueTime:           *(*int64)(unsafe.Pointer(&statusStruct[48])),
+	}
+
+	for i := 0; i < numLanes; i++ {
+		status.Lanes = append(status.Lanes, parseLaneStatus(laneStructs[i*laneStatusSize:]))
 	}
 
 	return status, nil
 }
+
+// ConfigureConnectionLanes 配置连接的通道（lane）
+// 配置后可通过 OutgoingMessage.Lane 指定消息所属的通道，
+// GetConnectionRealTimeStatus 也会返回每个通道的状态
+func (s *steamNetworkingSockets) ConfigureConnectionLanes(conn Connection, lanes []LaneConfig) error {
+	if err := s.require("ConfigureConnectionLanes"); err != nil {
+		return err
+	}
+
+	if conn == InvalidConnection {
+		return &Error{Op: "ConfigureConnectionLanes", Connection: conn, Err: ErrInvalidConnection}
+	}
+
+	priorities, weights, err := marshalLaneConfigs(lanes)
+	if err != nil {
+		e := withOp("ConfigureConnectionLanes", err)
+		e.Connection = conn
+		return e
+	}
+
+	result := purego.CallConfigureConnectionLanes(
+		s.handle,
+		uint32(conn),
+		int32(len(lanes)),
+		uintptr(unsafe.Pointer(&priorities[0])),
+		uintptr(unsafe.Pointer(&weights[0])),
+	)
+
+	if err := resultError("ConfigureConnectionLanes", conn, steamkit.EResult(result), nil); err != nil {
+		return err
+	}
+
+	globalLaneManager.set(conn, len(lanes))
+	return nil
+}
+
+// RunCallbacks 分发网络回调（如连接状态变化）
+// 应在主循环中定期调用，SetConnectionStatusChangedCallback 和 SetConnectionCallback
+// 设置的回调会在此调用期间、在调用方的 goroutine 上执行
+func (s *steamNetworkingSockets) RunCallbacks() {
+	if s.require("RunCallbacks") != nil {
+		return
+	}
+	purego.CallRunCallbacksSockets(s.handle)
+}
@@ -1,22 +1,39 @@
 package steamnet
 
 import (
+	"encoding/binary"
+	"runtime"
 	"testing"
+	"unsafe"
 )
 
 // MockSockets 是 ISteamNetworkingSockets 的 mock 实现
 type MockSockets struct {
-	CreateListenSocketP2PFunc func(int, []ConfigValue) (ListenSocket, error)
-	ConnectP2PFunc            func(Identity, int, []ConfigValue) (Connection, error)
-	AcceptConnectionFunc      func(Connection) error
-	CloseConnectionFunc       func(Connection, int, string, bool) error
-	CloseListenSocketFunc     func(ListenSocket) error
-	GetConnectionInfoFunc     func(Connection) (*ConnectionInfo, error)
-	GetConnectionRealTimeStatusFunc func(Connection) (*QuickConnectionStatus, error)
-	SendMessageToConnectionFunc func(Connection, []byte, SendFlags) error
-	FlushMessagesOnConnectionFunc func(Connection) error
-	ReceiveMessagesOnConnectionFunc func(Connection, int) ([]*Message, error)
-	ReceiveMessagesOnPollGroupFunc func(PollGroup, int) ([]*Message, error)
+	CreateListenSocketP2PFunc        func(int, []ConfigValue) (ListenSocket, error)
+	ConnectP2PFunc                   func(Identity, int, []ConfigValue) (Connection, error)
+	AcceptConnectionFunc             func(Connection) error
+	AcceptConnectionWithUserDataFunc func(Connection, int64, string) error
+	SetConnectionUserDataFunc        func(Connection, int64) error
+	GetConnectionUserDataFunc        func(Connection) (int64, error)
+	SetConnectionNameFunc            func(Connection, string) error
+	GetConnectionNameFunc            func(Connection) (string, error)
+	CloseConnectionFunc              func(Connection, ConnectionEndReason, string, bool) error
+	CloseListenSocketFunc            func(ListenSocket) error
+	CreateListenSocketIPFunc         func(Identity, []ConfigValue) (ListenSocket, error)
+	ConnectByIPAddressFunc           func(Identity, []ConfigValue) (Connection, error)
+	GetConnectionInfoFunc            func(Connection) (*ConnectionInfo, error)
+	GetConnectionRealTimeStatusFunc  func(Connection) (*QuickConnectionStatus, error)
+	ConfigureConnectionLanesFunc     func(Connection, []LaneConfig) error
+	RunCallbacksFunc                 func()
+	SendMessageToConnectionFunc      func(Connection, []byte, SendFlags) error
+	SendMessagesFunc                 func([]OutgoingMessage) []SendResult
+	FlushMessagesOnConnectionFunc    func(Connection) error
+	ReceiveMessagesOnConnectionFunc  func(Connection, int) ([]*Message, error)
+	ReceiveMessagesOnPollGroupFunc   func(PollGroup, int) ([]*Message, error)
+	CreatePollGroupFunc              func() (PollGroup, error)
+	DestroyPollGroupFunc             func(PollGroup) error
+	SetConnectionPollGroupFunc       func(Connection, PollGroup) error
+	GetPollGroupConnectionsFunc      func(PollGroup) ([]Connection, error)
 }
 
 func (m *MockSockets) CreateListenSocketP2P(virtualPort int, options []ConfigValue) (ListenSocket, error) {
@@ -40,7 +57,50 @@ func (m *MockSockets) AcceptConnection(conn Connection) error {
 	return nil
 }
 
-func (m *MockSockets) CloseConnection(conn Connection, reason int, debug string, linger bool) error {
+func (m *MockSockets) AcceptConnectionWithUserData(conn Connection, userData int64, name string) error {
+	if m.AcceptConnectionWithUserDataFunc != nil {
+		return m.AcceptConnectionWithUserDataFunc(conn, userData, name)
+	}
+	if err := m.SetConnectionUserData(conn, userData); err != nil {
+		return err
+	}
+	if name != "" {
+		if err := m.SetConnectionName(conn, name); err != nil {
+			return err
+		}
+	}
+	return m.AcceptConnection(conn)
+}
+
+func (m *MockSockets) SetConnectionUserData(conn Connection, userData int64) error {
+	if m.SetConnectionUserDataFunc != nil {
+		return m.SetConnectionUserDataFunc(conn, userData)
+	}
+	return nil
+}
+
+func (m *MockSockets) GetConnectionUserData(conn Connection) (int64, error) {
+	if m.GetConnectionUserDataFunc != nil {
+		return m.GetConnectionUserDataFunc(conn)
+	}
+	return 0, nil
+}
+
+func (m *MockSockets) SetConnectionName(conn Connection, name string) error {
+	if m.SetConnectionNameFunc != nil {
+		return m.SetConnectionNameFunc(conn, name)
+	}
+	return nil
+}
+
+func (m *MockSockets) GetConnectionName(conn Connection) (string, error) {
+	if m.GetConnectionNameFunc != nil {
+		return m.GetConnectionNameFunc(conn)
+	}
+	return "", nil
+}
+
+func (m *MockSockets) CloseConnection(conn Connection, reason ConnectionEndReason, debug string, linger bool) error {
 	if m.CloseConnectionFunc != nil {
 		return m.CloseConnectionFunc(conn, reason, debug, linger)
 	}
@@ -54,6 +114,20 @@ func (m *MockSockets) CloseListenSocket(socket ListenSocket) error {
 	return nil
 }
 
+func (m *MockSockets) CreateListenSocketIP(localAddr Identity, options []ConfigValue) (ListenSocket, error) {
+	if m.CreateListenSocketIPFunc != nil {
+		return m.CreateListenSocketIPFunc(localAddr, options)
+	}
+	return ListenSocket(1), nil
+}
+
+func (m *MockSockets) ConnectByIPAddress(addr Identity, options []ConfigValue) (Connection, error) {
+	if m.ConnectByIPAddressFunc != nil {
+		return m.ConnectByIPAddressFunc(addr, options)
+	}
+	return Connection(1), nil
+}
+
 func (m *MockSockets) GetConnectionInfo(conn Connection) (*ConnectionInfo, error) {
 	if m.GetConnectionInfoFunc != nil {
 		return m.GetConnectionInfoFunc(conn)
@@ -68,6 +142,17 @@ func (m *MockSockets) SendMessageToConnection(conn Connection, data []byte, flag
 	return nil
 }
 
+func (m *MockSockets) SendMessages(messages []OutgoingMessage) []SendResult {
+	if m.SendMessagesFunc != nil {
+		return m.SendMessagesFunc(messages)
+	}
+	results := make([]SendResult, len(messages))
+	for i := range results {
+		results[i].MessageNumber = int64(i + 1)
+	}
+	return results
+}
+
 func (m *MockSockets) FlushMessagesOnConnection(conn Connection) error {
 	if m.FlushMessagesOnConnectionFunc != nil {
 		return m.FlushMessagesOnConnectionFunc(conn)
@@ -89,6 +174,34 @@ func (m *MockSockets) ReceiveMessagesOnPollGroup(group PollGroup, maxMessages in
 	return []*Message{}, nil
 }
 
+func (m *MockSockets) CreatePollGroup() (PollGroup, error) {
+	if m.CreatePollGroupFunc != nil {
+		return m.CreatePollGroupFunc()
+	}
+	return PollGroup(1), nil
+}
+
+func (m *MockSockets) DestroyPollGroup(group PollGroup) error {
+	if m.DestroyPollGroupFunc != nil {
+		return m.DestroyPollGroupFunc(group)
+	}
+	return nil
+}
+
+func (m *MockSockets) SetConnectionPollGroup(conn Connection, group PollGroup) error {
+	if m.SetConnectionPollGroupFunc != nil {
+		return m.SetConnectionPollGroupFunc(conn, group)
+	}
+	return nil
+}
+
+func (m *MockSockets) GetPollGroupConnections(group PollGroup) ([]Connection, error) {
+	if m.GetPollGroupConnectionsFunc != nil {
+		return m.GetPollGroupConnectionsFunc(group)
+	}
+	return []Connection{}, nil
+}
+
 func (m *MockSockets) GetConnectionRealTimeStatus(conn Connection) (*QuickConnectionStatus, error) {
 	if m.GetConnectionRealTimeStatusFunc != nil {
 		return m.GetConnectionRealTimeStatusFunc(conn)
@@ -99,6 +212,19 @@ func (m *MockSockets) GetConnectionRealTimeStatus(conn Connection) (*QuickConnec
 	}, nil
 }
 
+func (m *MockSockets) ConfigureConnectionLanes(conn Connection, lanes []LaneConfig) error {
+	if m.ConfigureConnectionLanesFunc != nil {
+		return m.ConfigureConnectionLanesFunc(conn, lanes)
+	}
+	return nil
+}
+
+func (m *MockSockets) RunCallbacks() {
+	if m.RunCallbacksFunc != nil {
+		m.RunCallbacksFunc()
+	}
+}
+
 // 测试 Mock 实现
 func TestMockSockets(t *testing.T) {
 	mock := &MockSockets{}
@@ -122,6 +248,24 @@ func TestMockSockets(t *testing.T) {
 		t.Error("ConnectP2P() returned invalid connection")
 	}
 
+	// 测试 CreateListenSocketIP
+	ipSocket, err := mock.CreateListenSocketIP(NewIdentityFromIPAddr("", 27015), nil)
+	if err != nil {
+		t.Errorf("CreateListenSocketIP() error = %v", err)
+	}
+	if ipSocket == InvalidListenSocket {
+		t.Error("CreateListenSocketIP() returned invalid socket")
+	}
+
+	// 测试 ConnectByIPAddress
+	ipConn, err := mock.ConnectByIPAddress(NewIdentityFromIPAddr("192.168.1.1", 27015), nil)
+	if err != nil {
+		t.Errorf("ConnectByIPAddress() error = %v", err)
+	}
+	if ipConn == InvalidConnection {
+		t.Error("ConnectByIPAddress() returned invalid connection")
+	}
+
 	// 测试 AcceptConnection
 	if err := mock.AcceptConnection(conn); err != nil {
 		t.Errorf("AcceptConnection() error = %v", err)
@@ -141,6 +285,21 @@ func TestMockSockets(t *testing.T) {
 		t.Errorf("CloseConnection() error = %v", err)
 	}
 
+	// 测试轮询组
+	group, err := mock.CreatePollGroup()
+	if err != nil {
+		t.Errorf("CreatePollGroup() error = %v", err)
+	}
+	if err := mock.SetConnectionPollGroup(conn, group); err != nil {
+		t.Errorf("SetConnectionPollGroup() error = %v", err)
+	}
+	if _, err := mock.GetPollGroupConnections(group); err != nil {
+		t.Errorf("GetPollGroupConnections() error = %v", err)
+	}
+	if err := mock.DestroyPollGroup(group); err != nil {
+		t.Errorf("DestroyPollGroup() error = %v", err)
+	}
+
 	// 测试 CloseListenSocket
 	if err := mock.CloseListenSocket(socket); err != nil {
 		t.Errorf("CloseListenSocket() error = %v", err)
@@ -162,7 +321,7 @@ func TestSocketsErrorHandling(t *testing.T) {
 			}
 			return nil
 		},
-		CloseConnectionFunc: func(conn Connection, reason int, debug string, linger bool) error {
+		CloseConnectionFunc: func(conn Connection, reason ConnectionEndReason, debug string, linger bool) error {
 			if conn == InvalidConnection {
 				return ErrInvalidConnection
 			}
@@ -198,9 +357,6 @@ func TestMessageSendReceive(t *testing.T) {
 			if conn == InvalidConnection {
 				return ErrInvalidConnection
 			}
-			if len(data) == 0 {
-				return ErrSendFailed
-			}
 			return nil
 		},
 		ReceiveMessagesOnConnectionFunc: func(conn Connection, maxMessages int) ([]*Message, error) {
@@ -227,10 +383,10 @@ func TestMessageSendReceive(t *testing.T) {
 		t.Errorf("SendMessageToConnection() error = %v", err)
 	}
 
-	// 测试发送空消息
+	// 测试发送空消息（允许零长度消息）
 	err = mock.SendMessageToConnection(testConn, []byte{}, SendReliable)
-	if !IsSendFailed(err) {
-		t.Errorf("SendMessageToConnection() with empty data should return ErrSendFailed, got %v", err)
+	if err != nil {
+		t.Errorf("SendMessageToConnection() with empty data error = %v, want nil", err)
 	}
 
 	// 测试接收消息
@@ -255,20 +411,19 @@ func TestMessageSendReceive(t *testing.T) {
 // 测试消息释放
 func TestMessageRelease(t *testing.T) {
 	msg := &Message{
-		Data:     []byte("test"),
-		cPtr:     0,
-		released: false,
+		Data: []byte("test"),
+		cPtr: 0,
 	}
 
 	// 测试释放
 	ReleaseMessage(msg)
-	if !msg.released {
+	if !msg.IsReleased() {
 		t.Error("Message should be marked as released")
 	}
 
 	// 测试重复释放（应该是安全的）
 	ReleaseMessage(msg)
-	if !msg.released {
+	if !msg.IsReleased() {
 		t.Error("Message should still be marked as released")
 	}
 
@@ -347,3 +502,267 @@ func TestGetConnectionRealTimeStatus(t *testing.T) {
 		t.Errorf("GetConnectionRealTimeStatus() with invalid connection should return ErrInvalidConnection, got %v", err)
 	}
 }
+
+// 测试解析 SteamNetConnectionInfo_t
+func TestParseConnectionInfo(t *testing.T) {
+	var b [connectionInfoSize]byte
+	binary.LittleEndian.PutUint32(b[0:], nativeIdentityTypeSteamID)
+	binary.LittleEndian.PutUint32(b[4:], 8)
+	binary.LittleEndian.PutUint64(b[8:], 76561198000000000)
+	binary.LittleEndian.PutUint64(b[136:], uint64(42))
+	binary.LittleEndian.PutUint32(b[144:], 7)
+	addr, _ := marshalIPAddr("10.0.0.2", 27015)
+	copy(b[148:], addr[:])
+	binary.LittleEndian.PutUint32(b[168:], uint32('i')<<16|uint32('a')<<8|uint32('d'))
+	binary.LittleEndian.PutUint32(b[172:], uint32('f')<<16|uint32('r')<<8|uint32('a'))
+	binary.LittleEndian.PutUint32(b[176:], uint32(ConnectionStateClosedByPeer))
+	binary.LittleEndian.PutUint32(b[180:], 1001)
+	copy(b[184:], "kicked by server")
+	copy(b[312:], "#12345 SteamID:76561198000000000")
+	binary.LittleEndian.PutUint32(b[440:], uint32(ConnectionInfoRelayed|ConnectionInfoUnencrypted))
+
+	info := parseConnectionInfo(b[:])
+
+	if !info.Identity.Equal(NewIdentityFromSteamID(76561198000000000)) {
+		t.Errorf("Identity = %v, want SteamID:76561198000000000", info.Identity)
+	}
+	if info.UserData != 42 {
+		t.Errorf("UserData = %d, want 42", info.UserData)
+	}
+	if info.ListenSocket != ListenSocket(7) {
+		t.Errorf("ListenSocket = %d, want 7", info.ListenSocket)
+	}
+	if info.RemoteAddr != NewIPAddrIPv4(0x0a000002, 27015) {
+		t.Errorf("RemoteAddr = %v, want %v", info.RemoteAddr, "10.0.0.2:27015")
+	}
+	if info.POPRemote.String() != "iad" || info.POPRelay.String() != "fra" {
+		t.Errorf("POPs = (%s, %s), want (iad, fra)", info.POPRemote, info.POPRelay)
+	}
+	if info.State != ConnectionStateClosedByPeer {
+		t.Errorf("State = %v, want %v", info.State, ConnectionStateClosedByPeer)
+	}
+	if info.EndReason != 1001 {
+		t.Errorf("EndReason = %d, want 1001", info.EndReason)
+	}
+	if info.EndDebug != "kicked by server" {
+		t.Errorf("EndDebug = %q, want %q", info.EndDebug, "kicked by server")
+	}
+	if info.Description != "#12345 SteamID:76561198000000000" {
+		t.Errorf("Description = %q", info.Description)
+	}
+	if !info.Flags.Has(ConnectionInfoRelayed) || !info.Flags.Has(ConnectionInfoUnencrypted) || info.Flags.Has(ConnectionInfoFast) {
+		t.Errorf("Flags = %b, want Relayed|Unencrypted", info.Flags)
+	}
+}
+
+// 测试远程地址未知时为零值
+func TestParseConnectionInfo_NoRemoteAddr(t *testing.T) {
+	var b [connectionInfoSize]byte
+	info := parseConnectionInfo(b[:])
+	if info.RemoteAddr != (IPAddr{}) {
+		t.Errorf("RemoteAddr = %v, want zero value", info.RemoteAddr)
+	}
+	if info.Identity.IsValid() {
+		t.Errorf("Identity = %v, want invalid", info.Identity)
+	}
+}
+
+// 测试解析 SteamNetworkingMessage_t
+func TestParseMessage(t *testing.T) {
+	payload := []byte("Hello, Steam!")
+
+	// 固定在堆上，避免栈增长移动 b 导致 cPtr 比较失败
+	b := new([messageSize]byte)
+	var pinner runtime.Pinner
+	pinner.Pin(b)
+	defer pinner.Unpin()
+	binary.LittleEndian.PutUint64(b[0:], uint64(uintptr(unsafe.Pointer(&payload[0]))))
+	binary.LittleEndian.PutUint32(b[8:], uint32(len(payload)))
+	binary.LittleEndian.PutUint32(b[12:], 5)
+	binary.LittleEndian.PutUint32(b[16:], nativeIdentityTypeSteamID)
+	binary.LittleEndian.PutUint32(b[20:], 8)
+	binary.LittleEndian.PutUint64(b[24:], 76561198000000001)
+	binary.LittleEndian.PutUint64(b[152:], 17)
+	binary.LittleEndian.PutUint64(b[160:], 123456789)
+	binary.LittleEndian.PutUint64(b[168:], 99)
+	binary.LittleEndian.PutUint32(b[192:], 3)
+	binary.LittleEndian.PutUint32(b[196:], uint32(SendReliable))
+	binary.LittleEndian.PutUint16(b[208:], 2)
+
+	msg := parseMessage(uintptr(unsafe.Pointer(&b[0])), InvalidConnection)
+	runtime.KeepAlive(payload)
+
+	if string(msg.Data) != string(payload) {
+		t.Errorf("Data = %q, want %q", msg.Data, payload)
+	}
+	if msg.Connection != Connection(5) {
+		t.Errorf("Connection = %d, want 5", msg.Connection)
+	}
+	if !msg.Identity.Equal(NewIdentityFromSteamID(76561198000000001)) {
+		t.Errorf("Identity = %v, want steamid:76561198000000001", msg.Identity)
+	}
+	if msg.UserData != 17 {
+		t.Errorf("UserData = %d, want 17", msg.UserData)
+	}
+	if msg.TimeReceived != 123456789 {
+		t.Errorf("TimeReceived = %d, want 123456789", msg.TimeReceived)
+	}
+	if msg.MessageNumber != 99 {
+		t.Errorf("MessageNumber = %d, want 99", msg.MessageNumber)
+	}
+	if msg.Channel != 3 {
+		t.Errorf("Channel = %d, want 3", msg.Channel)
+	}
+	if msg.Flags != SendReliable {
+		t.Errorf("Flags = %v, want %v", msg.Flags, SendReliable)
+	}
+	if msg.Lane != 2 {
+		t.Errorf("Lane = %d, want 2", msg.Lane)
+	}
+	if msg.cPtr != uintptr(unsafe.Pointer(&b[0])) {
+		t.Error("cPtr should point to the native message")
+	}
+
+	// 指定连接时优先使用调用方的连接
+	msg = parseMessage(uintptr(unsafe.Pointer(&b[0])), Connection(9))
+	if msg.Connection != Connection(9) {
+		t.Errorf("Connection = %d, want 9", msg.Connection)
+	}
+}
+
+// 测试解析空消息
+func TestParseMessage_Empty(t *testing.T) {
+	var b [messageSize]byte
+	msg := decodeMessage(b[:])
+	if msg.Data == nil || len(msg.Data) != 0 {
+		t.Errorf("Data = %v, want empty slice", msg.Data)
+	}
+}
+
+// 测试批量发送
+func TestSendMessages(t *testing.T) {
+	mock := &MockSockets{
+		SendMessagesFunc: func(messages []OutgoingMessage) []SendResult {
+			results := make([]SendResult, len(messages))
+			for i, msg := range messages {
+				if err := msg.validate(); err != nil {
+					results[i].Err = err
+					continue
+				}
+				results[i].MessageNumber = int64(100 + i)
+			}
+			return results
+		},
+	}
+
+	results := mock.SendMessages([]OutgoingMessage{
+		{Connection: Connection(1), Data: []byte("state"), Flags: SendUnreliable, Lane: 0},
+		{Connection: InvalidConnection, Data: []byte("lost"), Flags: SendReliable},
+		{Connection: Connection(2), Data: nil, Flags: SendReliable, Lane: 1},
+		{Connection: Connection(3), Data: []byte("x"), Lane: -1},
+	})
+
+	if len(results) != 4 {
+		t.Fatalf("SendMessages() returned %d results, want 4", len(results))
+	}
+	if results[0].Err != nil || results[0].MessageNumber != 100 {
+		t.Errorf("results[0] = %+v, want message number 100", results[0])
+	}
+	if !IsInvalidConnection(results[1].Err) {
+		t.Errorf("results[1].Err = %v, want ErrInvalidConnection", results[1].Err)
+	}
+	if results[2].Err != nil {
+		t.Errorf("results[2].Err = %v, want nil for zero-length message", results[2].Err)
+	}
+	if !IsInvalidMessage(results[3].Err) {
+		t.Errorf("results[3].Err = %v, want ErrInvalidMessage", results[3].Err)
+	}
+}
+
+// 测试编码待发送消息
+func TestEncodeOutgoingMessage(t *testing.T) {
+	var b [messageSize]byte
+	encodeOutgoingMessage(b[:], OutgoingMessage{
+		Connection: Connection(42),
+		Flags:      SendReliableNoNagle,
+		Lane:       3,
+		UserData:   -5,
+	})
+
+	if got := binary.LittleEndian.Uint32(b[12:]); got != 42 {
+		t.Errorf("m_conn = %d, want 42", got)
+	}
+	if got := SendFlags(binary.LittleEndian.Uint32(b[196:])); got != SendReliableNoNagle {
+		t.Errorf("m_nFlags = %v, want %v", got, SendReliableNoNagle)
+	}
+	if got := int64(binary.LittleEndian.Uint64(b[200:])); got != -5 {
+		t.Errorf("m_nUserData = %d, want -5", got)
+	}
+	if got := binary.LittleEndian.Uint16(b[208:]); got != 3 {
+		t.Errorf("m_idxLane = %d, want 3", got)
+	}
+
+	// 编码后的消息应能被 decodeMessage 读回
+	msg := decodeMessage(b[:])
+	if msg.Connection != Connection(42) || msg.Flags != SendReliableNoNagle || msg.Lane != 3 {
+		t.Errorf("decodeMessage() = %+v, want round-trip of encoded fields", msg)
+	}
+}
+
+// 测试连接用户数据和名称
+func TestConnectionUserDataAndName(t *testing.T) {
+	userData := make(map[Connection]int64)
+	names := make(map[Connection]string)
+	var accepted []Connection
+
+	mock := &MockSockets{
+		SetConnectionUserDataFunc: func(conn Connection, data int64) error {
+			if conn == InvalidConnection {
+				return ErrInvalidConnection
+			}
+			userData[conn] = data
+			return nil
+		},
+		GetConnectionUserDataFunc: func(conn Connection) (int64, error) {
+			data, ok := userData[conn]
+			if !ok {
+				return -1, ErrInvalidConnection
+			}
+			return data, nil
+		},
+		SetConnectionNameFunc: func(conn Connection, name string) error {
+			names[conn] = name
+			return nil
+		},
+		GetConnectionNameFunc: func(conn Connection) (string, error) {
+			return names[conn], nil
+		},
+		AcceptConnectionFunc: func(conn Connection) error {
+			// 接受时用户数据必须已经设置
+			if _, ok := userData[conn]; !ok {
+				t.Error("user data should be set before AcceptConnection")
+			}
+			accepted = append(accepted, conn)
+			return nil
+		},
+	}
+
+	conn := Connection(7)
+	if err := mock.AcceptConnectionWithUserData(conn, 3, "player-3"); err != nil {
+		t.Fatalf("AcceptConnectionWithUserData() error = %v", err)
+	}
+
+	if got, err := mock.GetConnectionUserData(conn); err != nil || got != 3 {
+		t.Errorf("GetConnectionUserData() = (%d, %v), want (3, nil)", got, err)
+	}
+	if got, err := mock.GetConnectionName(conn); err != nil || got != "player-3" {
+		t.Errorf("GetConnectionName() = (%q, %v), want (player-3, nil)", got, err)
+	}
+	if len(accepted) != 1 || accepted[0] != conn {
+		t.Errorf("accepted = %v, want [%d]", accepted, conn)
+	}
+
+	if err := mock.AcceptConnectionWithUserData(InvalidConnection, 1, ""); !IsInvalidConnection(err) {
+		t.Errorf("AcceptConnectionWithUserData(invalid) error = %v, want ErrInvalidConnection", err)
+	}
+}
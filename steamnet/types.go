@@ -1,6 +1,12 @@
 // Package steamnet 提供 Steam 网络功能的 Go 语言绑定
 package steamnet
 
+import (
+	"fmt"
+	"math"
+	"sync/atomic"
+)
+
 // Connection 表示一个网络连接的句柄
 type Connection uint32
 
@@ -57,6 +63,11 @@ const (
 	SendUnreliableNoNagle SendFlags = 1 // 不可靠，立即发送（禁用 Nagle 算法）
 	SendReliable          SendFlags = 8 // 可靠，有序
 	SendReliableNoNagle   SendFlags = 9 // 可靠，立即发送
+
+	// 可与上述模式组合的附加标志
+	SendNoDelay                  SendFlags = 4  // 无法立即发送时丢弃（仅不可靠消息）
+	SendUseCurrentThread         SendFlags = 16 // 在当前线程发送，而不是交给后台线程
+	SendAutoRestartBrokenSession SendFlags = 32 // 会话中断时自动重连（仅 ISteamNetworkingMessages）
 )
 
 // String 返回发送标志的字符串表示
@@ -77,42 +88,94 @@ func (f SendFlags) String() string {
 
 // Message 表示接收到的网络消息
 type Message struct {
-	Data         []byte     // 消息数据
-	Connection   Connection // 来源连接
-	Identity     Identity   // 发送者身份
-	UserData     int64      // 用户数据
-	TimeReceived int64      // 接收时间（微秒）
+	Data          []byte     // 消息数据
+	Connection    Connection // 来源连接
+	Identity      Identity   // 发送者身份
+	UserData      int64      // 连接的用户数据
+	TimeReceived  int64      // 接收时间（微秒）
+	MessageNumber int64      // 消息序号
+	Flags         SendFlags  // 发送方使用的发送标志
+	Lane          int        // 所属通道（lane）索引
+	Channel       int        // 频道（仅用于 ISteamNetworkingMessages）
 
 	// 内部字段
-	cPtr     uintptr // C 指针，用于释放
-	released bool    // 是否已释放
+	cPtr       uintptr          // C 指针，用于释放
+	generation uint64           // 接收时的生命周期代数，Shutdown 之后原生消息已随 Steam API 一起释放
+	released   atomic.Bool      // 是否已释放
+	leakMeta   *messageLeakMeta // 泄漏检测信息（仅在启用调试模式时存在）
+}
+
+// OutgoingMessage 表示待批量发送的消息
+type OutgoingMessage struct {
+	Connection Connection // 目标连接
+	Data       []byte     // 消息数据（可以为空）
+	Flags      SendFlags  // 发送标志
+	Lane       int        // 通道（lane）索引
+	UserData   int64      // 消息用户数据（仅本地使用，不会发送给对方）
 }
 
-// Release 释放消息内存
-// 必须对每个接收到的消息调用此方法
-func (m *Message) Release() {
-	if m.released {
-		return
+// validate 检查待发送消息的参数
+func (m *OutgoingMessage) validate() error {
+	if m.Connection == InvalidConnection {
+		return &Error{Op: "SendMessages", Err: ErrInvalidConnection}
 	}
-	if m.cPtr != 0 {
-		// 调用 Steam API 释放消息
-		// 需要导入 internal/purego 包
-		// purego.CallReleaseMessage(m.cPtr)
-		// 为了避免循环依赖，这里暂时不调用
-		// 实际使用时需要在 sockets.go 中处理
+	if m.Lane < 0 || m.Lane > math.MaxUint16 {
+		return &Error{Op: "SendMessages", Message: fmt.Sprintf("lane %d out of range", m.Lane), Err: ErrInvalidMessage}
 	}
-	m.released = true
+	return nil
 }
 
-// ConnectionInfo 包含连接的详细信息
+// SendResult 表示批量发送中单条消息的结果
+type SendResult struct {
+	MessageNumber int64 // 消息序号（成功时有效）
+	Err           error // 发送失败的原因
+}
+
+// ConnectionInfo 包含连接的详细信息（对应 SteamNetConnectionInfo_t）
 type ConnectionInfo struct {
-	Identity     Identity        // 远程身份
-	UserData     int64           // 用户数据
-	ListenSocket ListenSocket    // 监听套接字（如果是传入连接）
-	RemoteAddr   string          // 远程地址
-	State        ConnectionState // 连接状态
-	EndReason    int             // 结束原因
-	EndDebug     string          // 调试信息
+	Identity     Identity            // 远程身份
+	UserData     int64               // 用户数据
+	ListenSocket ListenSocket        // 监听套接字（如果是传入连接）
+	RemoteAddr   IPAddr              // 远程地址（未知时为零值）
+	POPRemote    POPID               // 远程主机所在的数据中心
+	POPRelay     POPID               // 中继所在的数据中心
+	State        ConnectionState     // 连接状态
+	EndReason    ConnectionEndReason // 结束原因
+	EndDebug     string              // 调试信息
+	Description  string              // 连接描述
+	Flags        ConnectionInfoFlags // 连接标志
+}
+
+// POPID 表示 Valve 数据中心（POP）的标识（对应 SteamNetworkingPOPID）
+type POPID uint32
+
+// String 返回数据中心代码，例如 "iad"
+func (id POPID) String() string {
+	if id == 0 {
+		return ""
+	}
+	code := []byte{byte(id >> 16), byte(id >> 8), byte(id)}
+	if c := byte(id >> 24); c != 0 {
+		code = append(code, c)
+	}
+	return string(code)
+}
+
+// ConnectionInfoFlags 表示连接的标志位
+type ConnectionInfoFlags int32
+
+const (
+	ConnectionInfoUnauthenticated ConnectionInfoFlags = 1  // 未认证
+	ConnectionInfoUnencrypted     ConnectionInfoFlags = 2  // 未加密
+	ConnectionInfoLoopbackBuffers ConnectionInfoFlags = 4  // 进程内回环
+	ConnectionInfoFast            ConnectionInfoFlags = 8  // 低延迟直连
+	ConnectionInfoRelayed         ConnectionInfoFlags = 16 // 经过中继
+	ConnectionInfoDualWifi        ConnectionInfoFlags = 32 // 双 Wi-Fi
+)
+
+// Has 检查是否包含指定标志
+func (f ConnectionInfoFlags) Has(flag ConnectionInfoFlags) bool {
+	return f&flag == flag
 }
 
 // QuickConnectionStatus 包含连接的快速状态信息
@@ -128,22 +191,19 @@ type QuickConnectionStatus struct {
 	PendingUnreliable   int             // 待发送的不可靠数据
 	PendingReliable     int             // 待发送的可靠数据
 	SentUnackedReliable int             // 已发送但未确认的可靠数据
-}
-
-// ConfigValue 表示配置选项
-type ConfigValue struct {
-	Type  int         // 配置类型
-	Value interface{} // 配置值
+	QueueTime           int64           // 新消息的预计排队时间（微秒）
+	Lanes               []LaneStatus    // 每个通道的状态（仅在配置了通道时存在）
 }
 
 // ConnectionStatusChangedInfo 包含连接状态变化的信息
 type ConnectionStatusChangedInfo struct {
-	Connection Connection      // 连接句柄
-	Identity   Identity        // 远程身份
-	OldState   ConnectionState // 旧状态
-	NewState   ConnectionState // 新状态
-	EndReason  int             // 结束原因
-	EndDebug   string          // 调试信息
+	Connection Connection          // 连接句柄
+	Identity   Identity            // 远程身份
+	OldState   ConnectionState     // 旧状态
+	NewState   ConnectionState     // 新状态
+	EndReason  ConnectionEndReason // 结束原因
+	EndDebug   string              // 调试信息
+	Info       ConnectionInfo      // 变化后的完整连接信息
 }
 
 // AuthenticationStatus 包含认证状态信息
@@ -64,18 +64,46 @@ func TestMessage_Release(t *testing.T) {
 	msg := &Message{
 		Data:       []byte("test"),
 		Connection: Connection(1),
-		released:   false,
 	}
 
 	// 第一次释放
 	msg.Release()
-	if !msg.released {
+	if !msg.IsReleased() {
 		t.Error("Message should be marked as released")
 	}
 
 	// 第二次释放（应该是安全的）
 	msg.Release()
-	if !msg.released {
+	if !msg.IsReleased() {
 		t.Error("Message should still be marked as released")
 	}
 }
+
+func TestPOPID_String(t *testing.T) {
+	tests := []struct {
+		id       POPID
+		expected string
+	}{
+		{POPID('i')<<16 | POPID('a')<<8 | POPID('d'), "iad"},
+		{POPID('2')<<24 | POPID('s')<<16 | POPID('g')<<8 | POPID('p'), "sgp2"},
+		{0, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if got := tt.id.String(); got != tt.expected {
+				t.Errorf("POPID.String() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestConnectionInfoFlags_Has(t *testing.T) {
+	flags := ConnectionInfoUnauthenticated | ConnectionInfoRelayed
+	if !flags.Has(ConnectionInfoRelayed) {
+		t.Error("Has(Relayed) = false, want true")
+	}
+	if flags.Has(ConnectionInfoFast) {
+		t.Error("Has(Fast) = true, want false")
+	}
+}
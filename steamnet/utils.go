@@ -0,0 +1,349 @@
+package steamnet
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"unsafe"
+
+	"github.com/guowei-gong/steamkit-go/internal/purego"
+)
+
+// ISteamNetworkingUtils 定义网络工具接口（配置管理）
+type ISteamNetworkingUtils interface {
+	// 全局配置
+	SetGlobalConfigValueInt32(key ConfigKey, value int32) error
+	SetGlobalConfigValueFloat(key ConfigKey, value float32) error
+	SetGlobalConfigValueString(key ConfigKey, value string) error
+	SetGlobalConfigValuePtr(key ConfigKey, value uintptr) error
+
+	// 连接配置
+	SetConnectionConfigValueInt32(conn Connection, key ConfigKey, value int32) error
+	SetConnectionConfigValueFloat(conn Connection, key ConfigKey, value float32) error
+	SetConnectionConfigValueString(conn Connection, key ConfigKey, value string) error
+
+	// 通用配置（任意作用域和数据类型）
+	SetConfigValue(scope ConfigScope, scopeObj uintptr, value ConfigValue) error
+	GetConfigValue(key ConfigKey, scope ConfigScope, scopeObj uintptr) (*ConfigSetting, error)
+	GetConfigValueInfo(key ConfigKey) (*ConfigValueInfo, error)
+	IterateGenericEditableConfigValues(current ConfigKey, enumerateDevVars bool) ConfigKey
+	ListConfigValues(scope ConfigScope, scopeObj uintptr, enumerateDevVars bool) ([]ConfigSetting, error)
+
+	// FakeIP
+	GetIPv4FakeIPType(ip uint32) FakeIPType
+}
+
+// GetConfigValue 的原生返回值（ESteamNetworkingGetConfigValueResult）
+const (
+	getConfigValueBadValue       = -1
+	getConfigValueBadScopeObj    = -2
+	getConfigValueBufferTooSmall = -3
+	getConfigValueOK             = 1
+	getConfigValueOKInherited    = 2
+)
+
+// steamNetworkingUtils 是 ISteamNetworkingUtils 的实现
+type steamNetworkingUtils struct {
+	handle     uintptr
+	generation uint64 // 获取句柄时的生命周期代数，Shutdown 后句柄失效
+}
+
+// GetUtils 返回 ISteamNetworkingUtils 接口实例
+// 未初始化时返回 ErrNotInitialized；Shutdown 之后返回的实例失效，需要在重新 Init 后再次获取
+func GetUtils() (ISteamNetworkingUtils, error) {
+	if err := checkInitialized(); err != nil {
+		return nil, &Error{Op: "GetUtils", Err: err}
+	}
+	handle := purego.CallGetSteamNetworkingUtils()
+	if handle == 0 {
+		return nil, &Error{Op: "GetUtils", Message: "ISteamNetworkingUtils is not available", Err: ErrNotInitialized}
+	}
+	return &steamNetworkingUtils{
+		handle:     handle,
+		generation: purego.Generation(),
+	}, nil
+}
+
+// require 检查句柄是否仍然有效以及 ISteamNetworkingUtils 方法是否可用
+// 返回的 *Error 以 methods[0] 作为 Op
+func (u *steamNetworkingUtils) require(methods ...string) error {
+	if err := checkGeneration(u.generation); err != nil {
+		return &Error{Op: methods[0], Err: err}
+	}
+	if err := requireUtils(methods...); err != nil {
+		return withOp(methods[0], err)
+	}
+	return nil
+}
+
+// checkKey 检查配置项是否与期望的数据类型匹配，返回的错误以 op 作为 Op
+// 与配置选项使用相同的规则（ConfigValue.Validate），未知配置项交给原生层判断
+func checkKey(op string, key ConfigKey, dataType ConfigDataType) error {
+	if err := (ConfigValue{key: key, dataType: dataType}).Validate(); err != nil {
+		return withOp(op, err)
+	}
+	return nil
+}
+
+// setFailed 返回 op 设置配置失败的错误
+func setFailed(op string, key ConfigKey, scope ConfigScope) *Error {
+	return &Error{Op: op, Message: fmt.Sprintf("failed to set %s at %s scope", key, scope), Err: ErrInvalidConfigValue}
+}
+
+// SetGlobalConfigValueInt32 设置 int32 类型的全局配置
+func (u *steamNetworkingUtils) SetGlobalConfigValueInt32(key ConfigKey, value int32) error {
+	if err := u.require("SetGlobalConfigValueInt32"); err != nil {
+		return err
+	}
+	if err := checkKey("SetGlobalConfigValueInt32", key, ConfigDataTypeInt32); err != nil {
+		return err
+	}
+	if !purego.CallSetGlobalConfigValueInt32(u.handle, int32(key), value) {
+		return setFailed("SetGlobalConfigValueInt32", key, ConfigScopeGlobal)
+	}
+	return nil
+}
+
+// SetGlobalConfigValueFloat 设置 float 类型的全局配置
+func (u *steamNetworkingUtils) SetGlobalConfigValueFloat(key ConfigKey, value float32) error {
+	if err := u.require("SetGlobalConfigValueFloat"); err != nil {
+		return err
+	}
+	if err := checkKey("SetGlobalConfigValueFloat", key, ConfigDataTypeFloat); err != nil {
+		return err
+	}
+	if !purego.CallSetGlobalConfigValueFloat(u.handle, int32(key), value) {
+		return setFailed("SetGlobalConfigValueFloat", key, ConfigScopeGlobal)
+	}
+	return nil
+}
+
+// SetGlobalConfigValueString 设置字符串类型的全局配置
+func (u *steamNetworkingUtils) SetGlobalConfigValueString(key ConfigKey, value string) error {
+	if err := u.require("SetGlobalConfigValueString"); err != nil {
+		return err
+	}
+	if err := checkKey("SetGlobalConfigValueString", key, ConfigDataTypeString); err != nil {
+		return err
+	}
+	if !purego.CallSetGlobalConfigValueString(u.handle, int32(key), value) {
+		return setFailed("SetGlobalConfigValueString", key, ConfigScopeGlobal)
+	}
+	return nil
+}
+
+// SetGlobalConfigValuePtr 设置指针类型的全局配置
+func (u *steamNetworkingUtils) SetGlobalConfigValuePtr(key ConfigKey, value uintptr) error {
+	if err := u.require("SetGlobalConfigValuePtr"); err != nil {
+		return err
+	}
+	if err := checkKey("SetGlobalConfigValuePtr", key, ConfigDataTypePtr); err != nil {
+		return err
+	}
+	if !purego.CallSetGlobalConfigValuePtr(u.handle, int32(key), value) {
+		return setFailed("SetGlobalConfigValuePtr", key, ConfigScopeGlobal)
+	}
+	return nil
+}
+
+// SetConnectionConfigValueInt32 设置 int32 类型的连接配置
+func (u *steamNetworkingUtils) SetConnectionConfigValueInt32(conn Connection, key ConfigKey, value int32) error {
+	if err := u.require("SetConnectionConfigValueInt32"); err != nil {
+		return err
+	}
+	if conn == InvalidConnection {
+		return &Error{Op: "SetConnectionConfigValueInt32", Connection: conn, Err: ErrInvalidConnection}
+	}
+	if err := checkKey("SetConnectionConfigValueInt32", key, ConfigDataTypeInt32); err != nil {
+		return err
+	}
+	if !purego.CallSetConnectionConfigValueInt32(u.handle, uint32(conn), int32(key), value) {
+		e := setFailed("SetConnectionConfigValueInt32", key, ConfigScopeConnection)
+		e.Connection = conn
+		return e
+	}
+	return nil
+}
+
+// SetConnectionConfigValueFloat 设置 float 类型的连接配置
+func (u *steamNetworkingUtils) SetConnectionConfigValueFloat(conn Connection, key ConfigKey, value float32) error {
+	if err := u.require("SetConnectionConfigValueFloat"); err != nil {
+		return err
+	}
+	if conn == InvalidConnection {
+		return &Error{Op: "SetConnectionConfigValueFloat", Connection: conn, Err: ErrInvalidConnection}
+	}
+	if err := checkKey("SetConnectionConfigValueFloat", key, ConfigDataTypeFloat); err != nil {
+		return err
+	}
+	if !purego.CallSetConnectionConfigValueFloat(u.handle, uint32(conn), int32(key), value) {
+		e := setFailed("SetConnectionConfigValueFloat", key, ConfigScopeConnection)
+		e.Connection = conn
+		return e
+	}
+	return nil
+}
+
+// SetConnectionConfigValueString 设置字符串类型的连接配置
+func (u *steamNetworkingUtils) SetConnectionConfigValueString(conn Connection, key ConfigKey, value string) error {
+	if err := u.require("SetConnectionConfigValueString"); err != nil {
+		return err
+	}
+	if conn == InvalidConnection {
+		return &Error{Op: "SetConnectionConfigValueString", Connection: conn, Err: ErrInvalidConnection}
+	}
+	if err := checkKey("SetConnectionConfigValueString", key, ConfigDataTypeString); err != nil {
+		return err
+	}
+	if !purego.CallSetConnectionConfigValueString(u.handle, uint32(conn), int32(key), value) {
+		e := setFailed("SetConnectionConfigValueString", key, ConfigScopeConnection)
+		e.Connection = conn
+		return e
+	}
+	return nil
+}
+
+// SetConfigValue 在指定作用域设置配置
+// scopeObj 为作用域对象：全局作用域传 0，监听套接字和连接作用域传对应的句柄
+func (u *steamNetworkingUtils) SetConfigValue(scope ConfigScope, scopeObj uintptr, value ConfigValue) error {
+	if err := u.require("SetConfigValue"); err != nil {
+		return err
+	}
+	if err := checkKey("SetConfigValue", value.key, value.dataType); err != nil {
+		return err
+	}
+
+	buf, arg := configValueArg(value)
+	if arg == 0 {
+		return &Error{Op: "SetConfigValue", Message: fmt.Sprintf("%s has no value", value.key), Err: ErrInvalidConfigValue}
+	}
+
+	success := purego.CallSetConfigValue(u.handle, int32(value.key), int32(scope), scopeObj, int32(value.dataType), arg)
+	runtime.KeepAlive(buf)
+	if !success {
+		return setFailed("SetConfigValue", value.key, scope)
+	}
+	return nil
+}
+
+// GetConfigValue 获取指定作用域的配置值
+func (u *steamNetworkingUtils) GetConfigValue(key ConfigKey, scope ConfigScope, scopeObj uintptr) (*ConfigSetting, error) {
+	if err := u.require("GetConfigValue", "GetConfigValueInfo"); err != nil {
+		return nil, err
+	}
+
+	info, err := u.GetConfigValueInfo(key)
+	if err != nil {
+		return nil, withOp("GetConfigValue", err)
+	}
+
+	var dataType int32
+	buf := make([]byte, 8)
+	if info.DataType == ConfigDataTypeString {
+		buf = make([]byte, 256)
+	}
+
+	for {
+		size := uintptr(len(buf))
+		result := purego.CallGetConfigValue(
+			u.handle,
+			int32(key),
+			int32(scope),
+			scopeObj,
+			uintptr(unsafe.Pointer(&dataType)),
+			uintptr(unsafe.Pointer(&buf[0])),
+			uintptr(unsafe.Pointer(&size)),
+		)
+
+		switch result {
+		case getConfigValueOK, getConfigValueOKInherited:
+			return &ConfigSetting{
+				ConfigValueInfo: *info,
+				Value:           decodeConfigValue(key, ConfigDataType(dataType), buf[:size]),
+				Inherited:       result == getConfigValueOKInherited,
+			}, nil
+		case getConfigValueBufferTooSmall:
+			if size <= uintptr(len(buf)) {
+				return nil, &Error{Op: "GetConfigValue", Message: fmt.Sprintf("buffer too small for %s", key), Err: ErrInvalidConfigValue}
+			}
+			buf = make([]byte, size)
+		case getConfigValueBadScopeObj:
+			return nil, &Error{Op: "GetConfigValue", Message: fmt.Sprintf("bad scope object for %s at %s scope", key, scope), Err: ErrInvalidConfigValue}
+		default:
+			return nil, &Error{Op: "GetConfigValue", Message: fmt.Sprintf("failed to get %s: result=%d", key, result), Err: ErrInvalidConfigValue}
+		}
+	}
+}
+
+// GetConfigValueInfo 获取配置项的名称、数据类型和作用域
+func (u *steamNetworkingUtils) GetConfigValueInfo(key ConfigKey) (*ConfigValueInfo, error) {
+	if err := u.require("GetConfigValueInfo"); err != nil {
+		return nil, err
+	}
+
+	var dataType, scope int32
+	name := purego.CallGetConfigValueInfo(
+		u.handle,
+		int32(key),
+		uintptr(unsafe.Pointer(&dataType)),
+		uintptr(unsafe.Pointer(&scope)),
+	)
+	if name == "" {
+		return nil, &Error{Op: "GetConfigValueInfo", Message: fmt.Sprintf("unknown config key %d", int32(key)), Err: ErrInvalidConfigValue}
+	}
+
+	return &ConfigValueInfo{
+		Key:      key,
+		Name:     name,
+		DataType: ConfigDataType(dataType),
+		Scope:    ConfigScope(scope),
+	}, nil
+}
+
+// IterateGenericEditableConfigValues 返回 current 之后的下一个可编辑配置项
+// 传入 ConfigInvalid 开始遍历，返回 ConfigInvalid 表示遍历结束
+func (u *steamNetworkingUtils) IterateGenericEditableConfigValues(current ConfigKey, enumerateDevVars bool) ConfigKey {
+	if u.require("IterateGenericEditableConfigValues") != nil {
+		return ConfigInvalid
+	}
+	return ConfigKey(purego.CallIterateGenericEditableConfigValues(u.handle, int32(current), enumerateDevVars))
+}
+
+// ListConfigValues 列出所有可编辑配置项及其在指定作用域的当前值
+// 只列出可以在 scope 设置的配置项（例如连接作用域不包含仅全局的配置项）
+// 部分配置项读取失败时仍返回其余配置项，同时返回汇总了这些失败的错误
+func (u *steamNetworkingUtils) ListConfigValues(scope ConfigScope, scopeObj uintptr, enumerateDevVars bool) ([]ConfigSetting, error) {
+	if err := u.require("IterateGenericEditableConfigValues", "GetConfigValue", "GetConfigValueInfo"); err != nil {
+		return nil, err
+	}
+
+	var settings []ConfigSetting
+	var errs []error
+	for key := u.IterateGenericEditableConfigValues(ConfigInvalid, enumerateDevVars); key != ConfigInvalid; key = u.IterateGenericEditableConfigValues(key, enumerateDevVars) {
+		info, err := u.GetConfigValueInfo(key)
+		if err != nil {
+			errs = append(errs, withOp("ListConfigValues", err))
+			continue
+		}
+		// 配置项的作用域是它能设置的最窄作用域，更窄的作用域中没有该配置项
+		if info.Scope < scope {
+			continue
+		}
+		setting, err := u.GetConfigValue(key, scope, scopeObj)
+		if err != nil {
+			errs = append(errs, withOp("ListConfigValues", err))
+			continue
+		}
+		settings = append(settings, *setting)
+	}
+	return settings, errors.Join(errs...)
+}
+
+// GetIPv4FakeIPType 返回主机字节序 IPv4 地址的 FakeIP 类型
+// 方法不可用时返回 FakeIPTypeInvalid
+func (u *steamNetworkingUtils) GetIPv4FakeIPType(ip uint32) FakeIPType {
+	if u.require("GetIPv4FakeIPType") != nil {
+		return FakeIPTypeInvalid
+	}
+	return FakeIPType(purego.CallGetIPv4FakeIPType(u.handle, ip))
+}
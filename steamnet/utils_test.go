@@ -0,0 +1,44 @@
+package steamnet
+
+import (
+	"testing"
+)
+
+func TestCheckKey(t *testing.T) {
+	if err := checkKey("SetConfigValue", ConfigTimeoutConnected, ConfigDataTypeInt32); err != nil {
+		t.Errorf("checkKey(TimeoutConnected, Int32) error = %v, want nil", err)
+	}
+
+	if err := checkKey("SetConfigValue", ConfigTimeoutConnected, ConfigDataTypeString); !IsInvalidConfigValue(err) {
+		t.Errorf("checkKey(TimeoutConnected, String) error = %v, want ErrInvalidConfigValue", err)
+	}
+
+	// 未知配置项交给原生层判断，与 ConfigValue.Validate 的规则一致
+	if err := checkKey("SetConfigValue", ConfigKey(9999), ConfigDataTypeInt32); err != nil {
+		t.Errorf("checkKey(unknown) error = %v, want nil", err)
+	}
+	if err := NewConfigValueInt32(ConfigKey(9999), 1).Validate(); err != nil {
+		t.Errorf("Validate(unknown) error = %v, want nil", err)
+	}
+	if err := checkKey("SetConfigValue", ConfigInvalid, ConfigDataTypeInt32); !IsInvalidConfigValue(err) {
+		t.Errorf("checkKey(ConfigInvalid) error = %v, want ErrInvalidConfigValue", err)
+	}
+}
+
+func TestDecodeConfigValue_String(t *testing.T) {
+	buf := []byte("stun.example.com\x00garbage")
+	v := decodeConfigValue(ConfigP2PSTUNServerList, ConfigDataTypeString, buf)
+	if v.Str() != "stun.example.com" {
+		t.Errorf("Str() = %q, want %q", v.Str(), "stun.example.com")
+	}
+}
+
+func TestDecodeConfigValue_ShortBuffer(t *testing.T) {
+	v := decodeConfigValue(ConfigConnectionUserData, ConfigDataTypeInt64, []byte{1, 2})
+	if v.DataType() != ConfigDataTypeInvalid {
+		t.Errorf("DataType() = %v, want %v", v.DataType(), ConfigDataTypeInvalid)
+	}
+	if v.Key() != ConfigConnectionUserData {
+		t.Errorf("Key() = %v, want %v", v.Key(), ConfigConnectionUserData)
+	}
+}
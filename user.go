@@ -0,0 +1,40 @@
+package steamkit
+
+import (
+	"fmt"
+
+	"github.com/guowei-gong/steamkit-go/internal/purego"
+)
+
+// maxEncryptedAppTicketSize 是加密应用票据缓冲区的大小
+const maxEncryptedAppTicketSize = 1024
+
+// RequestEncryptedAppTicket 请求加密应用票据
+// data 会被包含在票据中（最多 128 字节），结果就绪后调用 GetEncryptedAppTicket 取回票据
+//
+//	res, err := steamkit.RequestEncryptedAppTicket(nil).Await(ctx)
+func RequestEncryptedAppTicket(data []byte) *CallResult[EncryptedAppTicketResponse] {
+	if err := purego.CheckInitialized(); err != nil {
+		return failedCallResult[EncryptedAppTicketResponse](err)
+	}
+	if err := purego.Require("SteamAPI_ISteamUser_RequestEncryptedAppTicket"); err != nil {
+		return failedCallResult[EncryptedAppTicketResponse](err)
+	}
+	return NewCallResult[EncryptedAppTicketResponse](APICall(purego.CallRequestEncryptedAppTicket(data)))
+}
+
+// GetEncryptedAppTicket 获取最近一次请求到的加密应用票据
+func GetEncryptedAppTicket() ([]byte, error) {
+	if err := purego.CheckInitialized(); err != nil {
+		return nil, err
+	}
+	if err := purego.Require("SteamAPI_ISteamUser_GetEncryptedAppTicket"); err != nil {
+		return nil, err
+	}
+	buf := make([]byte, maxEncryptedAppTicketSize)
+	size, ok := purego.CallGetEncryptedAppTicket(buf)
+	if !ok {
+		return nil, fmt.Errorf("steamkit: no encrypted app ticket available")
+	}
+	return buf[:size], nil
+}